@@ -9,8 +9,8 @@ require (
 	github.com/go-logr/logr v0.2.1 // indirect
 	github.com/go-openapi/strfmt v0.19.5 // indirect
 	github.com/go-ozzo/ozzo-validation/v3 v3.8.1
-	github.com/google/go-cmp v0.5.0 // indirect
 	github.com/google/gofuzz v1.2.0 // indirect
+	github.com/google/uuid v1.1.2
 	github.com/hashicorp/go-version v1.2.1
 	github.com/imdario/mergo v0.3.11 // indirect
 	github.com/jedib0t/go-pretty v4.3.0+incompatible
@@ -22,14 +22,16 @@ require (
 	github.com/riser-platform/riser-server/api/v1/model v0.0.21
 	github.com/riser-platform/riser-server/pkg/sdk v0.0.48
 	github.com/shurcooL/httpfs v0.0.0-20190707220628-8d4bc4ba7749
-	github.com/spf13/cobra v1.0.0
+	github.com/spf13/cobra v1.2.1
 	github.com/spf13/pflag v1.0.5
-	github.com/stretchr/testify v1.6.1
+	github.com/stretchr/testify v1.7.0
 	github.com/whilp/git-urls v0.0.0-20191001220047-6db9661140c0
 	github.com/wzshiming/ctc v1.2.3
-	golang.org/x/tools v0.0.0-20200717024301-6ddee64345a6 // indirect
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
+	golang.org/x/sys v0.0.0-20210510120138-977fb7262007
 	gopkg.in/check.v1 v1.0.0-20200227125254-8fa46927fb4f // indirect
-	gopkg.in/yaml.v2 v2.3.0
+	gopkg.in/yaml.v2 v2.4.0
 	k8s.io/apimachinery v0.19.0
+	k8s.io/client-go v0.19.0
 	k8s.io/klog/v2 v2.3.0 // indirect
 )
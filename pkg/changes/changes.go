@@ -0,0 +1,135 @@
+// Package changes finds the git commits between docker tags deployed to riser
+package changes
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Commit is a commit from git log
+type Commit struct {
+	Sha      string `json:"sha"`
+	ShortSha string `json:"shortSha"`
+	Author   string `json:"author"`
+	Subject  string `json:"subject"`
+}
+
+// Group is a group of commits of the same type (e.g. features or fixes)
+type Group struct {
+	Title   string   `json:"title"`
+	Commits []Commit `json:"commits"`
+}
+
+// Git runs a git command and returns its stdout
+type Git func(args ...string) (string, error)
+
+// LocalGit runs git in the current working directory
+func LocalGit(args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "git %s: %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+var semverExp = regexp.MustCompile(`^v?\d+\.\d+\.\d+([-+].*)?$`)
+
+// ResolveRef returns the commit for a docker tag. The tag may be a commit SHA or a tag in the repository. Semver tags
+// are also matched with or without a "v" prefix (e.g. the docker tag "1.2.3" matches the git tag "v1.2.3").
+func ResolveRef(git Git, dockerTag string) (string, error) {
+	candidates := []string{dockerTag}
+	if semverExp.MatchString(dockerTag) {
+		if strings.HasPrefix(dockerTag, "v") {
+			candidates = append(candidates, strings.TrimPrefix(dockerTag, "v"))
+		} else {
+			candidates = append(candidates, "v"+dockerTag)
+		}
+	}
+
+	for _, candidate := range candidates {
+		out, err := git("rev-parse", "--verify", "--quiet", candidate+"^{commit}")
+		if err == nil {
+			return strings.TrimSpace(out), nil
+		}
+	}
+
+	return "", fmt.Errorf("the docker tag %q is not a commit or a tag in the current git repository", dockerTag)
+}
+
+const logFieldSeparator = "\x1f"
+
+// Log returns the commits reachable from toRef but not from fromRef, newest first. Merge commits are excluded.
+func Log(git Git, fromRef, toRef string) ([]Commit, error) {
+	out, err := git("log", "--no-merges", "--format=%H%x1f%h%x1f%an%x1f%s", fmt.Sprintf("%s..%s", fromRef, toRef))
+	if err != nil {
+		return nil, err
+	}
+	return parseLog(out), nil
+}
+
+func parseLog(out string) []Commit {
+	commits := []Commit{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, logFieldSeparator, 4)
+		if len(fields) != 4 {
+			continue
+		}
+		commits = append(commits, Commit{Sha: fields[0], ShortSha: fields[1], Author: fields[2], Subject: fields[3]})
+	}
+	return commits
+}
+
+// conventionalCommitExp matches a conventional commit subject (e.g. "feat(api)!: add foo")
+var conventionalCommitExp = regexp.MustCompile(`^(\w+)(\([^)]*\))?(!)?:\s*(.+)$`)
+
+const (
+	groupBreaking = "Breaking Changes"
+	groupFeatures = "Features"
+	groupFixes    = "Fixes"
+	groupOther    = "Other Changes"
+)
+
+var groupsByType = map[string]string{
+	"feat":     groupFeatures,
+	"feature":  groupFeatures,
+	"fix":      groupFixes,
+	"bugfix":   groupFixes,
+	"perf":     groupFixes,
+	"security": groupFixes,
+}
+
+var groupOrder = []string{groupBreaking, groupFeatures, groupFixes, groupOther}
+
+// GroupCommits groups commits by their conventional commit type. Commits that do not follow the conventional commit
+// format are grouped as other changes. Empty groups are omitted.
+func GroupCommits(commits []Commit) []Group {
+	commitsByGroup := map[string][]Commit{}
+	for _, commit := range commits {
+		group := groupOther
+		if match := conventionalCommitExp.FindStringSubmatch(commit.Subject); match != nil {
+			if typeGroup, ok := groupsByType[strings.ToLower(match[1])]; ok {
+				group = typeGroup
+			}
+			if match[3] == "!" {
+				group = groupBreaking
+			}
+		}
+		commitsByGroup[group] = append(commitsByGroup[group], commit)
+	}
+
+	groups := []Group{}
+	for _, title := range groupOrder {
+		if len(commitsByGroup[title]) > 0 {
+			groups = append(groups, Group{Title: title, Commits: commitsByGroup[title]})
+		}
+	}
+	return groups
+}
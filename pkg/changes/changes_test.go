@@ -0,0 +1,83 @@
+package changes
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeGit(refs map[string]string) Git {
+	return func(args ...string) (string, error) {
+		ref := strings.TrimSuffix(args[len(args)-1], "^{commit}")
+		if sha, ok := refs[ref]; ok {
+			return sha + "\n", nil
+		}
+		return "", errors.New("exit status 1")
+	}
+}
+
+func Test_ResolveRef(t *testing.T) {
+	git := fakeGit(map[string]string{"abc123": "abc123full", "v1.2.3": "v123sha", "2.0.0": "200sha"})
+	tests := []struct {
+		dockerTag string
+		expected  string
+		err       string
+	}{
+		{"abc123", "abc123full", ""},
+		{"v1.2.3", "v123sha", ""},
+		{"1.2.3", "v123sha", ""},
+		{"v2.0.0", "200sha", ""},
+		{"latest", "", `the docker tag "latest" is not a commit or a tag in the current git repository`},
+	}
+
+	for _, tt := range tests {
+		result, err := ResolveRef(git, tt.dockerTag)
+
+		if tt.err == "" {
+			assert.NoError(t, err, tt.dockerTag)
+		} else {
+			assert.Equal(t, tt.err, err.Error())
+		}
+		assert.Equal(t, tt.expected, result, tt.dockerTag)
+	}
+}
+
+func Test_Log(t *testing.T) {
+	var gitArgs []string
+	git := func(args ...string) (string, error) {
+		gitArgs = args
+		return "sha1\x1fs1\x1fJane Doe\x1ffeat: add foo\nsha2\x1fs2\x1fJohn\x1ffix: subject with \x1f separator\n", nil
+	}
+
+	result, err := Log(git, "from", "to")
+
+	require.NoError(t, err)
+	assert.Equal(t, "from..to", gitArgs[len(gitArgs)-1])
+	assert.Equal(t, []Commit{
+		{Sha: "sha1", ShortSha: "s1", Author: "Jane Doe", Subject: "feat: add foo"},
+		{Sha: "sha2", ShortSha: "s2", Author: "John", Subject: "fix: subject with \x1f separator"},
+	}, result)
+}
+
+func Test_GroupCommits(t *testing.T) {
+	commits := []Commit{
+		{Subject: "fix(api): handle nil"},
+		{Subject: "Update README"},
+		{Subject: "feat: add foo"},
+		{Subject: "feat(api)!: remove bar"},
+		{Subject: "chore: bump deps"},
+		{Subject: "Feat: add baz"},
+	}
+
+	result := GroupCommits(commits)
+
+	assert.Equal(t, []Group{
+		{Title: "Breaking Changes", Commits: []Commit{{Subject: "feat(api)!: remove bar"}}},
+		{Title: "Features", Commits: []Commit{{Subject: "feat: add foo"}, {Subject: "Feat: add baz"}}},
+		{Title: "Fixes", Commits: []Commit{{Subject: "fix(api): handle nil"}}},
+		{Title: "Other Changes", Commits: []Commit{{Subject: "Update README"}, {Subject: "chore: bump deps"}}},
+	}, result)
+}
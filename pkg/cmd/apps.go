@@ -2,14 +2,10 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"riser/pkg/logger"
 	"riser/pkg/rc"
 	"riser/pkg/ui"
-	"riser/pkg/ui/style"
 
 	"github.com/riser-platform/riser-server/api/v1/model"
-	"github.com/riser-platform/riser-server/pkg/sdk"
 	"github.com/spf13/cobra"
 )
 
@@ -24,11 +20,15 @@ func newAppsCommand(config *rc.RuntimeConfiguration) *cobra.Command {
 	cmd.AddCommand(newAppsListCommand(config))
 	cmd.AddCommand(newAppsNewCommand(config))
 	cmd.AddCommand(newAppsInitCommand(config))
+	cmd.AddCommand(newAppsImportCommand(config))
+	cmd.AddCommand(newAppsDescribeCommand(config))
 
 	return cmd
 }
 
 func newAppsListCommand(config *rc.RuntimeConfiguration) *cobra.Command {
+	var namespace string
+	options := &listOptions{}
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "Lists all apps",
@@ -44,53 +44,66 @@ func newAppsListCommand(config *rc.RuntimeConfiguration) *cobra.Command {
 				view.AddRow(app.Name, app.Namespace, app.Id)
 			}
 
+			if namespace != "" {
+				ui.ExitIfError(view.Filter(ui.Selector{{Key: "Namespace", Operator: ui.SelectorOperatorEquals, Value: namespace}}))
+			}
+			ui.ExitIfError(options.apply(view))
+
 			ui.RenderView(view)
 		},
 	}
 
+	addNamespaceFilterFlag(cmd.Flags(), &namespace)
+	addListFlags(cmd.Flags(), options)
 	addOutputFlag(cmd.Flags())
 	return cmd
 }
 
-func newAppsInitCommand(config *rc.RuntimeConfiguration) *cobra.Command {
+func newAppsNewCommand(config *rc.RuntimeConfiguration) *cobra.Command {
 	var namespace string
 	cmd := &cobra.Command{
-		Use:   "init (app name)",
-		Short: "Creates a new app with a default app.yaml file",
+		Use:   "new (app name)",
+		Short: "Creates a new app",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			appName := args[0]
 			app := createNewApp(config, appName, namespace)
 
-			file, err := os.OpenFile(AppConfigPath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0644)
-			ui.ExitIfErrorMsg(err, "Error creating default app config")
-			defer file.Close()
-
-			err = sdk.DefaultAppConfig(file, app.Id, appName, namespace)
-			ui.ExitIfErrorMsg(err, "Error creating default app config")
-			logger.Log().Info(fmt.Sprintf("App %s created with a default app config file %q. Please review the TODO's before deploying your app.", style.Emphasis(appName), AppConfigPath))
+			view := ui.NewMessageView(
+				fmt.Sprintf("App %s created. Please add the following id to your manifest: %s", app.Name, app.Id),
+				app)
+			ui.RenderView(view)
 		},
 	}
 
 	addNamespaceFlag(cmd.Flags(), &namespace)
+	addOutputFlag(cmd.Flags())
 
 	return cmd
 }
 
-func newAppsNewCommand(config *rc.RuntimeConfiguration) *cobra.Command {
+func newAppsDescribeCommand(config *rc.RuntimeConfiguration) *cobra.Command {
 	var namespace string
 	cmd := &cobra.Command{
-		Use:   "new (app name)",
-		Short: "Creates a new app",
-		Args:  cobra.ExactArgs(1),
+		Use:               "describe (app name)",
+		Short:             "Describes an app and its deployments in all environments",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeAppNames(config)),
 		Run: func(cmd *cobra.Command, args []string) {
 			appName := args[0]
-			app := createNewApp(config, appName, namespace)
+			currentContext := safeCurrentContext(config)
+			riserClient := getRiserClient(currentContext)
 
-			view := ui.NewMessageView(
-				fmt.Sprintf("App %s created. Please add the following id to your manifest: %s", app.Name, app.Id),
-				app)
-			ui.RenderView(view)
+			app, err := riserClient.Apps.Get(appName, namespace)
+			ui.ExitIfError(err)
+
+			appStatus, err := riserClient.Apps.GetStatus(appName, namespace)
+			ui.ExitIfErrorMsg(err, "Error getting status")
+
+			gatewayHosts, err := getPublicGatewayHosts(riserClient, appStatus, map[string]string{})
+			ui.ExitIfErrorMsg(err, "Error getting environment config")
+
+			ui.RenderView(&appsDescribeView{app: app, status: appStatus, gatewayHosts: gatewayHosts})
 		},
 	}
 
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"riser/pkg/status"
+	"riser/pkg/ui"
+	"riser/pkg/ui/style"
+	"riser/pkg/ui/table"
+	"sort"
+	"strings"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+)
+
+type appsDescribeView struct {
+	app    *model.App
+	status *model.AppStatus
+	// gatewayHosts is the public gateway host by environment name
+	gatewayHosts map[string]string
+}
+
+type appDescribeModel struct {
+	Id          string                       `json:"id"`
+	Name        string                       `json:"name"`
+	Namespace   string                       `json:"namespace"`
+	Deployments []appDeploymentDescribeModel `json:"deployments"`
+}
+
+type appDeploymentDescribeModel struct {
+	Name               string                      `json:"name"`
+	Environment        string                      `json:"environment"`
+	EnvironmentHealthy bool                        `json:"environmentHealthy"`
+	RiserRevision      int64                       `json:"riserRevision"`
+	Status             string                      `json:"status"`
+	Reason             string                      `json:"reason,omitempty"`
+	Traffic            []appTrafficDescribeModel   `json:"traffic"`
+	Urls               deploymentDescribeUrlsModel `json:"urls"`
+}
+
+type appTrafficDescribeModel struct {
+	RiserRevision int64 `json:"riserRevision"`
+	Percent       int64 `json:"percent"`
+}
+
+func (view *appsDescribeView) model() *appDescribeModel {
+	namespace := string(view.app.Namespace)
+	describeModel := &appDescribeModel{
+		Id:          view.app.Id.String(),
+		Name:        string(view.app.Name),
+		Namespace:   namespace,
+		Deployments: []appDeploymentDescribeModel{},
+	}
+
+	environmentHealth := map[string]bool{}
+	for _, environmentStatus := range view.status.Environments {
+		environmentHealth[environmentStatus.EnvironmentName] = environmentStatus.Healthy
+	}
+
+	for idx := range view.status.Deployments {
+		deploymentStatus := &view.status.Deployments[idx]
+		deploymentModel := appDeploymentDescribeModel{
+			Name:          deploymentStatus.DeploymentName,
+			Environment:   deploymentStatus.EnvironmentName,
+			RiserRevision: deploymentStatus.RiserRevision,
+			Traffic:       []appTrafficDescribeModel{},
+			Urls: deploymentDescribeUrlsModel{
+				External: formatExternalUrl(deploymentStatus.DeploymentName, namespace, view.gatewayHosts[deploymentStatus.EnvironmentName]),
+				Cluster:  formatClusterLocalUrl(deploymentStatus.DeploymentName, namespace),
+			},
+		}
+
+		// Environments without a status have not yet reported and are assumed to be healthy
+		healthy, found := environmentHealth[deploymentStatus.EnvironmentName]
+		deploymentModel.EnvironmentHealthy = healthy || !found
+
+		for _, revision := range status.GetRevisionStatus(deploymentStatus, true) {
+			if revision.RiserRevision == deploymentStatus.RiserRevision {
+				deploymentModel.Status = revision.RevisionStatus
+				deploymentModel.Reason = revision.RevisionStatusReason
+			}
+			if revision.Traffic.Percent != nil && *revision.Traffic.Percent > 0 {
+				deploymentModel.Traffic = append(deploymentModel.Traffic, appTrafficDescribeModel{RiserRevision: revision.RiserRevision, Percent: *revision.Traffic.Percent})
+			}
+		}
+
+		describeModel.Deployments = append(describeModel.Deployments, deploymentModel)
+	}
+
+	sort.SliceStable(describeModel.Deployments, func(i, j int) bool {
+		if describeModel.Deployments[i].Name == describeModel.Deployments[j].Name {
+			return describeModel.Deployments[i].Environment < describeModel.Deployments[j].Environment
+		}
+		return describeModel.Deployments[i].Name < describeModel.Deployments[j].Name
+	})
+
+	return describeModel
+}
+
+func (view *appsDescribeView) RenderHuman(writer io.Writer) error {
+	describeModel := view.model()
+	outStr := ""
+	outStr += fmt.Sprintf("Name: %s\n", describeModel.Name)
+	outStr += fmt.Sprintf("Namespace: %s\n", describeModel.Namespace)
+	outStr += fmt.Sprintf("Id: %s\n", describeModel.Id)
+
+	if len(describeModel.Deployments) == 0 {
+		outStr += fmt.Sprintf("\nThere are no deployments for the app %q. Use \"riser deploy\" to make your first deployment.\n", describeModel.Name)
+	} else {
+		outStr += "\nDeployments:\n"
+		deploymentsTable := table.Default().Header("Deployment", "Env", "Rev", "Traffic", "Status", "External URL")
+		for _, deployment := range describeModel.Deployments {
+			deploymentsTable.AddRow(
+				deployment.Name,
+				deployment.Environment,
+				fmt.Sprintf("%d", deployment.RiserRevision),
+				formatAppTraffic(deployment.Traffic),
+				formatRevisionStatus(deployment.Status),
+				deployment.Urls.External,
+			)
+		}
+		outStr += deploymentsTable.String()
+		outStr += "\n\n"
+
+		for _, environmentStatus := range view.status.Environments {
+			if !environmentStatus.Healthy {
+				outStr += style.Warn(fmt.Sprintf("Warning: environment %q is not healthy. %s\n", environmentStatus.EnvironmentName, environmentStatus.Reason))
+			}
+		}
+	}
+
+	_, err := writer.Write([]byte(outStr))
+	return err
+}
+
+func (view *appsDescribeView) RenderJson(writer io.Writer) error {
+	return ui.RenderJson(view.model(), writer)
+}
+
+// formatAppTraffic formats traffic as a list of rules (e.g. "r2:10% r1:90%")
+func formatAppTraffic(traffic []appTrafficDescribeModel) string {
+	if len(traffic) == 0 {
+		return "0%"
+	}
+
+	rules := []string{}
+	for _, rule := range traffic {
+		rules = append(rules, fmt.Sprintf("r%d:%d%%", rule.RiserRevision, rule.Percent))
+	}
+	return strings.Join(rules, " ")
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"riser/pkg/util"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_appsDescribeView_RenderJson(t *testing.T) {
+	app := &model.App{Id: uuid.MustParse("c5a7a3bc-8d2b-4e0d-a9ac-9ad3f3a2d6e1"), Name: "myapp", Namespace: model.NamespaceName("apps")}
+	appStatus := &model.AppStatus{
+		Environments: []model.EnvironmentStatus{
+			{EnvironmentName: "env1", Healthy: true},
+			{EnvironmentName: "env2", Healthy: false, Reason: "down"},
+		},
+		Deployments: []model.DeploymentStatus{
+			{
+				DeploymentName:  "myapp",
+				EnvironmentName: "env2",
+				RiserRevision:   1,
+				DeploymentStatusMutable: model.DeploymentStatusMutable{
+					ObservedRiserRevision: 1,
+					Revisions: []model.DeploymentRevisionStatus{
+						{Name: "myapp-1", RiserRevision: 1, DockerImage: "foo:v1", RevisionStatus: model.RevisionStatusReady},
+					},
+					Traffic: []model.DeploymentTrafficStatus{
+						{RevisionName: "myapp-1", Percent: util.PtrInt64(100)},
+					},
+				},
+			},
+			{
+				DeploymentName:  "myapp",
+				EnvironmentName: "env1",
+				RiserRevision:   2,
+				DeploymentStatusMutable: model.DeploymentStatusMutable{
+					ObservedRiserRevision: 2,
+					Revisions: []model.DeploymentRevisionStatus{
+						{Name: "myapp-1", RiserRevision: 1, DockerImage: "foo:v1", RevisionStatus: model.RevisionStatusReady},
+						{Name: "myapp-2", RiserRevision: 2, DockerImage: "foo:v2", RevisionStatus: model.RevisionStatusWaiting, RevisionStatusReason: "pulling"},
+					},
+					Traffic: []model.DeploymentTrafficStatus{
+						{RevisionName: "myapp-1", Percent: util.PtrInt64(90)},
+						{RevisionName: "myapp-2", Percent: util.PtrInt64(10)},
+					},
+				},
+			},
+		},
+	}
+	view := &appsDescribeView{app: app, status: appStatus, gatewayHosts: map[string]string{"env1": "env1.riser", "env2": "env2.riser"}}
+
+	var b bytes.Buffer
+	err := view.RenderJson(&b)
+
+	assert.NoError(t, err)
+	result := &appDescribeModel{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), result))
+	assert.Equal(t, "c5a7a3bc-8d2b-4e0d-a9ac-9ad3f3a2d6e1", result.Id)
+	assert.Equal(t, "myapp", result.Name)
+	assert.Equal(t, "apps", result.Namespace)
+	// Sorted by deployment then environment
+	require.Len(t, result.Deployments, 2)
+	assert.Equal(t, "env1", result.Deployments[0].Environment)
+	assert.True(t, result.Deployments[0].EnvironmentHealthy)
+	assert.Equal(t, int64(2), result.Deployments[0].RiserRevision)
+	assert.Equal(t, model.RevisionStatusWaiting, result.Deployments[0].Status)
+	assert.Equal(t, "pulling", result.Deployments[0].Reason)
+	assert.ElementsMatch(t, []appTrafficDescribeModel{{RiserRevision: 1, Percent: 90}, {RiserRevision: 2, Percent: 10}}, result.Deployments[0].Traffic)
+	assert.Equal(t, "https://myapp.apps.env1.riser", result.Deployments[0].Urls.External)
+	assert.Equal(t, "http://myapp.apps.svc.cluster.local", result.Deployments[0].Urls.Cluster)
+	assert.Equal(t, "env2", result.Deployments[1].Environment)
+	assert.False(t, result.Deployments[1].EnvironmentHealthy)
+	assert.Equal(t, model.RevisionStatusReady, result.Deployments[1].Status)
+	assert.Equal(t, []appTrafficDescribeModel{{RiserRevision: 1, Percent: 100}}, result.Deployments[1].Traffic)
+	assert.Equal(t, "https://myapp.apps.env2.riser", result.Deployments[1].Urls.External)
+}
+
+func Test_formatAppTraffic(t *testing.T) {
+	tests := []struct {
+		traffic  []appTrafficDescribeModel
+		expected string
+	}{
+		{[]appTrafficDescribeModel{}, "0%"},
+		{[]appTrafficDescribeModel{{RiserRevision: 1, Percent: 100}}, "r1:100%"},
+		{[]appTrafficDescribeModel{{RiserRevision: 2, Percent: 10}, {RiserRevision: 1, Percent: 90}}, "r2:10% r1:90%"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, formatAppTraffic(tt.traffic))
+	}
+}
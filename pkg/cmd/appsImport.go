@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"riser/pkg/importer"
+	"riser/pkg/logger"
+	"riser/pkg/rc"
+	"riser/pkg/ui"
+	"riser/pkg/ui/style"
+
+	"github.com/ghodss/yaml"
+	"github.com/google/uuid"
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/spf13/cobra"
+)
+
+func newAppsImportCommand(config *rc.RuntimeConfiguration) *cobra.Command {
+	var namespace string
+	var manifestPath string
+	force := false
+	dryRun := false
+	cmd := &cobra.Command{
+		Use:   "import [app name]",
+		Short: "Creates a new app from an existing Kubernetes Deployment or Knative Service",
+		Long: "Creates a new app from an existing Kubernetes Deployment or Knative Service and writes its app.yaml file. " +
+			"The manifest may be a Deployment with its Services, Ingresses and HorizontalPodAutoscaler, a Knative Service, " +
+			"or the output of \"helm template\". The app name defaults to the name of the Deployment or Knative Service. " +
+			"Anything in the manifest that could not be imported is reported.",
+		Example: "  riser apps import --from-manifest deployment.yaml\n  helm template ./mychart | riser apps import myapp --from-manifest - --dry-run",
+		Args:    cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			manifest := io.Reader(os.Stdin)
+			if manifestPath != "-" {
+				manifestFile, err := os.Open(manifestPath)
+				ui.ExitIfErrorMsg(err, "Error reading manifest")
+				defer manifestFile.Close()
+				manifest = manifestFile
+			}
+
+			result, err := importer.FromManifest(manifest)
+			ui.ExitIfErrorMsg(err, "Error importing manifest")
+
+			appConfig := result.AppConfig
+			if len(args) > 0 {
+				appConfig.Name = model.AppName(args[0])
+			}
+			appConfig.Namespace = model.NamespaceName(namespace)
+
+			for _, unmapped := range result.Unmapped {
+				logger.Log().Warn(fmt.Sprintf("Not imported: %s", unmapped))
+			}
+
+			// Validate before creating the app. The id is assigned when the app is created.
+			validationConfig := *appConfig
+			validationConfig.Id = uuid.New()
+			err = validationConfig.Validate()
+			ui.ExitIfErrorMsg(err, "The imported app config is invalid. Use --dry-run to review it")
+
+			if dryRun {
+				appConfigYaml, err := marshalAppConfig(appConfig)
+				ui.ExitIfError(err)
+				fmt.Print(string(appConfigYaml))
+				return
+			}
+
+			if !force {
+				if _, err := os.Stat(AppConfigPath); err == nil {
+					ui.ExitErrorMsg(fmt.Sprintf("The app config file %q already exists. Use --force to overwrite it.", AppConfigPath))
+				}
+			}
+
+			app := createNewApp(config, string(appConfig.Name), namespace)
+			appConfig.Id = app.Id
+			appConfig.Namespace = app.Namespace
+
+			appConfigYaml, err := marshalAppConfig(appConfig)
+			ui.ExitIfErrorMsg(err, "Error creating app config")
+			err = ioutil.WriteFile(AppConfigPath, appConfigYaml, 0644)
+			ui.ExitIfErrorMsg(err, "Error creating app config")
+
+			logger.Log().Info(fmt.Sprintf("App %s imported to the app config file %q", style.Emphasis(string(appConfig.Name)), AppConfigPath))
+		},
+	}
+
+	addNamespaceFlag(cmd.Flags(), &namespace)
+	cmd.Flags().StringVar(&manifestPath, "from-manifest", "", "Path to a Kubernetes manifest, or \"-\" to read from stdin")
+	_ = cmd.MarkFlagRequired("from-manifest")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite the app config file if it already exists")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the app config without creating the app")
+
+	return cmd
+}
+
+// marshalAppConfig marshals an app config to yaml. The id is omitted if the app has not yet been created.
+func marshalAppConfig(appConfig *model.AppConfig) ([]byte, error) {
+	jsonBytes, err := json.Marshal(appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	appConfigMap := map[string]interface{}{}
+	err = json.Unmarshal(jsonBytes, &appConfigMap)
+	if err != nil {
+		return nil, err
+	}
+	if appConfig.Id == uuid.Nil {
+		delete(appConfigMap, "id")
+	}
+
+	return yaml.Marshal(appConfigMap)
+}
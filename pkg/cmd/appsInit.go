@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"riser/pkg/logger"
+	"riser/pkg/rc"
+	"riser/pkg/ui"
+	"riser/pkg/ui/style"
+	uiterminal "riser/pkg/ui/terminal"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/spf13/cobra"
+)
+
+const defaultAppInitTemplate = "http"
+
+// appInitOptions are the values used to generate an app config
+type appInitOptions struct {
+	Image           string
+	ContainerPort   int32
+	Protocol        string
+	Scope           string
+	HealthCheckPath string
+}
+
+type appInitTemplate struct {
+	description string
+	options     appInitOptions
+}
+
+var appInitTemplates = map[string]appInitTemplate{
+	"http": {
+		description: "An HTTP service exposed to the public gateway",
+		options:     appInitOptions{ContainerPort: 8000, Protocol: "http", Scope: model.AppExposeScope_External, HealthCheckPath: "/health"},
+	},
+	"grpc": {
+		description: "A gRPC service exposed to the public gateway",
+		options:     appInitOptions{ContainerPort: 50051, Protocol: "http2", Scope: model.AppExposeScope_External},
+	},
+	"worker": {
+		description: "A background worker that is only reachable from within the cluster",
+		options:     appInitOptions{ContainerPort: 8080, Protocol: "http", Scope: model.AppExposeScope_Cluster, HealthCheckPath: "/health"},
+	},
+}
+
+// appInitPlaceholderImage is used when no image is specified so that the app config is valid
+const appInitPlaceholderImage = "your/image"
+
+func newAppsInitCommand(config *rc.RuntimeConfiguration) *cobra.Command {
+	var namespace string
+	var templateName string
+	var dockerfilePath string
+	force := false
+	noPrompt := false
+	cmd := &cobra.Command{
+		Use:   "init (app name)",
+		Short: "Creates a new app with an app.yaml file",
+		Long: "Creates a new app with an app.yaml file. You will be prompted for the app's settings unless --no-prompt is " +
+			"specified or riser is not running in a terminal. Defaults for each setting are taken from the --template.\n\n" +
+			"Templates:\n" + formatAppInitTemplates(),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			appName := args[0]
+
+			initTemplate, ok := appInitTemplates[templateName]
+			if !ok {
+				ui.ExitError(ui.NewError(ui.ErrorCodeUsage, fmt.Sprintf("Unknown template %q. Must be one of: %s", templateName, strings.Join(appInitTemplateNames(), ", "))))
+			}
+			options := initTemplate.options
+
+			if dockerfilePath != "" {
+				dockerfile, err := os.Open(dockerfilePath)
+				ui.ExitIfErrorMsg(err, "Error reading Dockerfile")
+				port, err := parseDockerfileExposedPort(dockerfile)
+				dockerfile.Close()
+				ui.ExitIfErrorMsg(err, fmt.Sprintf("Unable to infer the container port from %q", dockerfilePath))
+				options.ContainerPort = port
+			}
+
+			if !force {
+				if _, err := os.Stat(AppConfigPath); err == nil {
+					ui.ExitErrorMsg(fmt.Sprintf("The app config file %q already exists. Use --force to overwrite it.", AppConfigPath))
+				}
+			}
+
+			if !noPrompt && uiterminal.IsStdinTerminal() {
+				ui.ExitIfError(promptAppInitOptions(&options))
+			}
+
+			app := createNewApp(config, appName, namespace)
+
+			flags := os.O_CREATE | os.O_WRONLY | os.O_EXCL
+			if force {
+				flags = os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+			}
+			file, err := os.OpenFile(AppConfigPath, flags, 0644)
+			ui.ExitIfErrorMsg(err, "Error creating app config")
+			defer file.Close()
+
+			err = writeAppConfig(file, app.Id, appName, string(app.Namespace), &options)
+			ui.ExitIfErrorMsg(err, "Error creating app config")
+
+			message := fmt.Sprintf("App %s created with the app config file %q.", style.Emphasis(appName), AppConfigPath)
+			if options.Image == "" {
+				message += " Please review the TODO's before deploying your app."
+			}
+			logger.Log().Info(message)
+		},
+	}
+
+	addNamespaceFlag(cmd.Flags(), &namespace)
+	cmd.Flags().StringVar(&templateName, "template", defaultAppInitTemplate, fmt.Sprintf("The template to use for default values. One of: %s", strings.Join(appInitTemplateNames(), "|")))
+	cmd.Flags().StringVar(&dockerfilePath, "from-dockerfile", "", "Path to a Dockerfile to infer the container port from its EXPOSE instruction")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite the app config file if it already exists")
+	cmd.Flags().BoolVar(&noPrompt, "no-prompt", false, "do not prompt for the app's settings")
+	_ = cmd.RegisterFlagCompletionFunc("template", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return appInitTemplateNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+func promptAppInitOptions(options *appInitOptions) error {
+	answers := struct {
+		Image           string
+		ContainerPort   string
+		Protocol        string
+		Scope           string
+		HealthCheckPath string
+	}{}
+
+	questions := []*survey.Question{
+		{
+			Name: "image",
+			Prompt: &survey.Input{
+				Message: "Docker image (without a tag)",
+				Default: options.Image,
+				Help:    "The docker image registry and repo (e.g. ghcr.io/your/image). Leave blank to fill this in later.",
+			},
+		},
+		{
+			Name:   "containerPort",
+			Prompt: &survey.Input{Message: "Container port", Default: strconv.Itoa(int(options.ContainerPort))},
+			Validate: func(ans interface{}) error {
+				_, err := parseContainerPort(ans.(string))
+				return err
+			},
+		},
+		{
+			Name:   "protocol",
+			Prompt: &survey.Select{Message: "Protocol", Options: []string{"http", "http2"}, Default: options.Protocol, Help: "Use http2 for gRPC"},
+		},
+		{
+			Name: "scope",
+			Prompt: &survey.Select{
+				Message: "Scope",
+				Options: []string{model.AppExposeScope_External, model.AppExposeScope_Cluster},
+				Default: options.Scope,
+				Help:    "External apps are exposed to the public gateway. Cluster apps are only reachable from within the cluster.",
+			},
+		},
+		{
+			Name:   "healthCheckPath",
+			Prompt: &survey.Input{Message: "Health check path", Default: options.HealthCheckPath, Help: "Leave blank to use riser's default health check"},
+		},
+	}
+
+	err := survey.Ask(questions, &answers)
+	if err != nil {
+		return err
+	}
+
+	port, err := parseContainerPort(answers.ContainerPort)
+	if err != nil {
+		return err
+	}
+
+	options.Image = strings.TrimSpace(answers.Image)
+	options.ContainerPort = port
+	options.Protocol = answers.Protocol
+	options.Scope = answers.Scope
+	options.HealthCheckPath = strings.TrimSpace(answers.HealthCheckPath)
+	return nil
+}
+
+func writeAppConfig(writer io.Writer, appId uuid.UUID, appName, appNamespace string, options *appInitOptions) error {
+	appConfig := &model.AppConfig{
+		Id:        appId,
+		Name:      model.AppName(appName),
+		Namespace: model.NamespaceName(appNamespace),
+		Image:     options.Image,
+		Expose: &model.AppConfigExpose{
+			ContainerPort: options.ContainerPort,
+			Protocol:      options.Protocol,
+			Scope:         options.Scope,
+		},
+	}
+	if options.HealthCheckPath != "" {
+		appConfig.HealthCheck = &model.AppConfigHealthCheck{Path: options.HealthCheckPath}
+	}
+
+	header := ""
+	if appConfig.Image == "" {
+		appConfig.Image = appInitPlaceholderImage
+		header = "# TODO: Update the image to use your docker image registry/repo (without tag)\n"
+	}
+
+	appConfigBytes, err := marshalAppConfig(appConfig)
+	if err != nil {
+		return errors.Wrap(err, "Error serializing app config")
+	}
+
+	_, err = io.WriteString(writer, header+string(appConfigBytes))
+	return err
+}
+
+// parseDockerfileExposedPort returns the first port exposed in the final stage of a Dockerfile
+func parseDockerfileExposedPort(reader io.Reader) (int32, error) {
+	var port string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "FROM":
+			// Only the final stage is used for the image
+			port = ""
+		case "EXPOSE":
+			if port == "" && len(fields) > 1 {
+				port = strings.SplitN(fields[1], "/", 2)[0]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	if port == "" {
+		return 0, errors.New("the Dockerfile does not contain an EXPOSE instruction")
+	}
+
+	return parseContainerPort(port)
+}
+
+func parseContainerPort(portStr string) (int32, error) {
+	port, err := strconv.ParseInt(strings.TrimSpace(portStr), 10, 32)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %q: must be a number between 1 and 65535", portStr)
+	}
+	return int32(port), nil
+}
+
+func appInitTemplateNames() []string {
+	names := []string{}
+	for name := range appInitTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func formatAppInitTemplates() string {
+	outStr := ""
+	for _, name := range appInitTemplateNames() {
+		outStr += fmt.Sprintf("  %-8s%s\n", name, appInitTemplates[name].description)
+	}
+	return outStr
+}
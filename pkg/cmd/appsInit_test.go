@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/google/uuid"
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseDockerfileExposedPort(t *testing.T) {
+	tests := []struct {
+		dockerfile string
+		expected   int32
+		err        string
+	}{
+		{"FROM alpine\nEXPOSE 8080\n", 8080, ""},
+		{"FROM alpine\nexpose 9000/tcp 9001\n", 9000, ""},
+		{"FROM golang AS build\nEXPOSE 1234\nFROM alpine\nEXPOSE 5678\nEXPOSE 9999\n", 5678, ""},
+		{"FROM golang AS build\nEXPOSE 1234\nFROM alpine\n", 0, "the Dockerfile does not contain an EXPOSE instruction"},
+		{"FROM alpine\n", 0, "the Dockerfile does not contain an EXPOSE instruction"},
+		{"FROM alpine\nEXPOSE $PORT\n", 0, `invalid port "$PORT": must be a number between 1 and 65535`},
+		{"FROM alpine\nEXPOSE 70000\n", 0, `invalid port "70000": must be a number between 1 and 65535`},
+	}
+
+	for _, tt := range tests {
+		result, err := parseDockerfileExposedPort(strings.NewReader(tt.dockerfile))
+
+		if tt.err != "" {
+			assert.Equal(t, tt.err, err.Error())
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		}
+	}
+}
+
+func Test_writeAppConfig(t *testing.T) {
+	appId := uuid.New()
+	for _, templateName := range appInitTemplateNames() {
+		options := appInitTemplates[templateName].options
+		options.Image = "ghcr.io/my/app"
+		var b bytes.Buffer
+
+		err := writeAppConfig(&b, appId, "myapp", "myns", &options)
+
+		require.NoError(t, err)
+		appConfig := &model.AppConfig{}
+		require.NoError(t, yaml.Unmarshal(b.Bytes(), appConfig), b.String())
+		assert.NoError(t, appConfig.Validate(), templateName)
+		assert.Equal(t, appId, appConfig.Id)
+		assert.EqualValues(t, "myapp", appConfig.Name)
+		assert.EqualValues(t, "myns", appConfig.Namespace)
+		assert.Equal(t, "ghcr.io/my/app", appConfig.Image)
+		assert.Equal(t, options.ContainerPort, appConfig.Expose.ContainerPort)
+		assert.Equal(t, options.Protocol, appConfig.Expose.Protocol)
+		assert.Equal(t, options.Scope, appConfig.Expose.Scope)
+		if options.HealthCheckPath == "" {
+			assert.Nil(t, appConfig.HealthCheck, templateName)
+		} else {
+			assert.Equal(t, options.HealthCheckPath, appConfig.HealthCheck.Path)
+		}
+	}
+}
+
+func Test_writeAppConfig_NoImage(t *testing.T) {
+	options := appInitTemplates[defaultAppInitTemplate].options
+	var b bytes.Buffer
+
+	err := writeAppConfig(&b, uuid.New(), "myapp", "myns", &options)
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(b.String(), "# TODO: Update the image to use your docker image registry/repo (without tag)\n"), b.String())
+	assert.Contains(t, b.String(), "\nimage: your/image\n")
+}
+
+func Test_writeAppConfig_EscapesValues(t *testing.T) {
+	options := appInitTemplates[defaultAppInitTemplate].options
+	options.Image = "registry: #1"
+	options.HealthCheckPath = "/health: #check"
+	var b bytes.Buffer
+
+	err := writeAppConfig(&b, uuid.New(), "myapp", "myns", &options)
+
+	require.NoError(t, err)
+	appConfig := &model.AppConfig{}
+	require.NoError(t, yaml.Unmarshal(b.Bytes(), appConfig), b.String())
+	assert.Equal(t, "registry: #1", appConfig.Image)
+	assert.Equal(t, "/health: #check", appConfig.HealthCheck.Path)
+}
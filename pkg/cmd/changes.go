@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"riser/pkg/changes"
+	"riser/pkg/rc"
+	"riser/pkg/ui"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/spf13/cobra"
+)
+
+func newChangesCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
+	var appName string
+	var deploymentName string
+	var namespace string
+	cmd := &cobra.Command{
+		Use:   "changes (fromEnvironment) (toEnvironment)",
+		Short: "Shows the git commits that would be promoted from one environment to another",
+		Long: "Shows the git commits that would be promoted from one environment to another as markdown. The docker tags of the Ready revisions " +
+			"with the most traffic are compared using \"git log\" in the current working directory. Docker tags must be a git commit SHA or a tag of the " +
+			"repository (e.g. \"v1.2.3\" or \"1.2.3\"). Commits are grouped using the conventional commit format (e.g. \"feat: add foo\").",
+		Example:           "  riser changes staging prod > release-notes.md",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeArgs(completeEnvironmentNames(runtimeConfig), completeEnvironmentNames(runtimeConfig)),
+		Run: func(cmd *cobra.Command, args []string) {
+			if deploymentName == "" {
+				deploymentName = appName
+			}
+
+			currentContext := safeCurrentContext(runtimeConfig)
+			riserClient := getRiserClient(currentContext)
+			appStatus, err := riserClient.Apps.GetStatus(appName, namespace)
+			ui.ExitIfErrorMsg(err, "Error getting status")
+
+			from, err := newChangesEnvironment(appStatus, deploymentName, args[0])
+			ui.ExitIfError(err)
+			to, err := newChangesEnvironment(appStatus, deploymentName, args[1])
+			ui.ExitIfError(err)
+
+			view := &changesView{appName: appName, deploymentName: deploymentName, from: from, to: to, commits: []changes.Commit{}}
+			if from.Tag != to.Tag {
+				from.Sha, err = changes.ResolveRef(changes.LocalGit, from.Tag)
+				ui.ExitIfErrorMsg(err, fmt.Sprintf("Error resolving the docker tag in the environment %q", from.Environment))
+				to.Sha, err = changes.ResolveRef(changes.LocalGit, to.Tag)
+				ui.ExitIfErrorMsg(err, fmt.Sprintf("Error resolving the docker tag in the environment %q", to.Environment))
+
+				view.commits, err = changes.Log(changes.LocalGit, to.Sha, from.Sha)
+				ui.ExitIfErrorMsg(err, "Error getting commits")
+				removed, err := changes.Log(changes.LocalGit, from.Sha, to.Sha)
+				ui.ExitIfErrorMsg(err, "Error getting commits")
+				view.removedCommits = len(removed)
+			}
+
+			ui.RenderView(view)
+		},
+	}
+
+	addAppFlag(cmd.Flags(), &appName)
+	addNamespaceFlag(cmd.Flags(), &namespace)
+	addOutputFlag(cmd.Flags())
+	cmd.Flags().StringVar(&deploymentName, "name", "", "The name of the deployment (e.g. \"myapp-foo\"). Defaults to the name of the app.")
+
+	return cmd
+}
+
+// newChangesEnvironment returns the docker tag of the Ready revision with the most traffic for a deployment in an environment
+func newChangesEnvironment(appStatus *model.AppStatus, deploymentName, environmentName string) (*changesEnvironmentModel, error) {
+	for idx := range appStatus.Deployments {
+		deploymentStatus := &appStatus.Deployments[idx]
+		if deploymentStatus.DeploymentName != deploymentName || deploymentStatus.EnvironmentName != environmentName {
+			continue
+		}
+		revision := runningRevision(deploymentStatus)
+		if revision == nil {
+			break
+		}
+		tag := dockerTag(revision.DockerImage)
+		if tag == "" {
+			return nil, ui.NewError(ui.ErrorCodeGeneral, fmt.Sprintf("The docker image %q in the environment %q does not have a tag", revision.DockerImage, environmentName))
+		}
+		return &changesEnvironmentModel{Environment: environmentName, DockerImage: revision.DockerImage, Tag: tag}, nil
+	}
+
+	return nil, ui.NewError(ui.ErrorCodeNotFound, fmt.Sprintf("The deployment %q does not have a Ready revision in the environment %q", deploymentName, environmentName))
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"riser/pkg/changes"
+	"riser/pkg/ui"
+)
+
+type changesView struct {
+	appName        string
+	deploymentName string
+	from           *changesEnvironmentModel
+	to             *changesEnvironmentModel
+	// commits are the commits in the "from" environment that are not in the "to" environment
+	commits []changes.Commit
+	// removedCommits is the number of commits in the "to" environment that are not in the "from" environment
+	removedCommits int
+}
+
+type changesModel struct {
+	App            string                   `json:"app"`
+	Deployment     string                   `json:"deployment"`
+	From           *changesEnvironmentModel `json:"from"`
+	To             *changesEnvironmentModel `json:"to"`
+	Groups         []changes.Group          `json:"groups"`
+	RemovedCommits int                      `json:"removedCommits"`
+}
+
+type changesEnvironmentModel struct {
+	Environment string `json:"environment"`
+	DockerImage string `json:"dockerImage"`
+	Tag         string `json:"tag"`
+	Sha         string `json:"sha,omitempty"`
+}
+
+// RenderHuman renders markdown so that it may be pasted into release notes or change tickets
+func (view *changesView) RenderHuman(writer io.Writer) error {
+	outStr := fmt.Sprintf("## Changes to %s\n\n", view.deploymentName)
+	outStr += fmt.Sprintf("Promoting **%s** (`%s`) to **%s** (`%s`): %d commit(s)\n",
+		view.from.Environment, view.from.Tag, view.to.Environment, view.to.Tag, len(view.commits))
+
+	if len(view.commits) == 0 {
+		outStr += "\nNo changes.\n"
+	}
+	for _, group := range changes.GroupCommits(view.commits) {
+		outStr += fmt.Sprintf("\n### %s\n\n", group.Title)
+		for _, commit := range group.Commits {
+			outStr += fmt.Sprintf("- %s (`%s`, %s)\n", commit.Subject, commit.ShortSha, commit.Author)
+		}
+	}
+
+	if view.removedCommits > 0 {
+		outStr += fmt.Sprintf("\n> **Note:** %s has %d commit(s) that are not in %s. These commits will be removed from %s.\n",
+			view.to.Environment, view.removedCommits, view.from.Environment, view.to.Environment)
+	}
+
+	_, err := writer.Write([]byte(outStr))
+	return err
+}
+
+func (view *changesView) RenderJson(writer io.Writer) error {
+	return ui.RenderJson(&changesModel{
+		App:            view.appName,
+		Deployment:     view.deploymentName,
+		From:           view.from,
+		To:             view.to,
+		Groups:         changes.GroupCommits(view.commits),
+		RemovedCommits: view.removedCommits,
+	}, writer)
+}
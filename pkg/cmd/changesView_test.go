@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"riser/pkg/changes"
+	"riser/pkg/util"
+	"testing"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newChangesEnvironment(t *testing.T) {
+	appStatus := &model.AppStatus{
+		Deployments: []model.DeploymentStatus{
+			{
+				DeploymentName:  "myapp",
+				EnvironmentName: "prod",
+				RiserRevision:   1,
+				DeploymentStatusMutable: model.DeploymentStatusMutable{
+					ObservedRiserRevision:     1,
+					LatestCreatedRevisionName: "rev1",
+					Revisions:                 []model.DeploymentRevisionStatus{{Name: "rev1", RiserRevision: 1, DockerImage: "myapp:v1.0.0", RevisionStatus: model.RevisionStatusReady}},
+					Traffic:                   []model.DeploymentTrafficStatus{{RevisionName: "rev1", Percent: util.PtrInt64(100)}},
+				},
+			},
+		},
+	}
+
+	result, err := newChangesEnvironment(appStatus, "myapp", "prod")
+
+	require.NoError(t, err)
+	assert.Equal(t, &changesEnvironmentModel{Environment: "prod", DockerImage: "myapp:v1.0.0", Tag: "v1.0.0"}, result)
+
+	_, err = newChangesEnvironment(appStatus, "myapp", "dev")
+
+	assert.Equal(t, `The deployment "myapp" does not have a Ready revision in the environment "dev"`, err.Error())
+}
+
+func Test_changesView_RenderHuman(t *testing.T) {
+	view := &changesView{
+		appName:        "myapp",
+		deploymentName: "myapp",
+		from:           &changesEnvironmentModel{Environment: "staging", Tag: "v1.1.0"},
+		to:             &changesEnvironmentModel{Environment: "prod", Tag: "v1.0.0"},
+		commits: []changes.Commit{
+			{ShortSha: "bbb", Author: "Jane", Subject: "fix: handle nil"},
+			{ShortSha: "aaa", Author: "John", Subject: "feat: add foo"},
+		},
+		removedCommits: 1,
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, view.RenderHuman(&b))
+
+	assert.Equal(t, "## Changes to myapp\n\n"+
+		"Promoting **staging** (`v1.1.0`) to **prod** (`v1.0.0`): 2 commit(s)\n"+
+		"\n### Features\n\n- feat: add foo (`aaa`, John)\n"+
+		"\n### Fixes\n\n- fix: handle nil (`bbb`, Jane)\n"+
+		"\n> **Note:** prod has 1 commit(s) that are not in staging. These commits will be removed from prod.\n", b.String())
+}
@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"os"
 	"riser/pkg/logger"
+	"riser/pkg/ui"
+	"riser/pkg/ui/style"
 
 	"github.com/spf13/cobra"
 )
 
 var verbose bool
+var contextName string
+var colorMode string
 
 // Execute creates the root command and executes it
 func Execute(runtime *Runtime) {
@@ -17,30 +21,56 @@ func Execute(runtime *Runtime) {
 		Use:   os.Args[0],
 		Short: "Riser platform",
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			logger.SetLogger(logger.NewScreenLogger(verbose))
+			ui.ExitIfErrorMsg(style.SetColorMode(colorMode), "Invalid --color flag")
+			cmdLogger, diagnosticLogger, err := newLoggers(cmd)
+			ui.ExitIfErrorMsg(err, "Unable to configure logging")
+			logger.SetLogger(cmdLogger)
+			logger.SetDiagnosticLogger(diagnosticLogger)
+			enableTracing(runtime.Version)
+			if contextName != "" {
+				ui.ExitIfError(runtime.Configuration.OverrideCurrentContext(contextName))
+			}
+			ui.ExitIfError(applyContextDefaultNamespace(cmd, runtime.Configuration))
 		},
 	}
 
 	cmd.AddCommand(newAppsCommand(runtime.Configuration))
+	cmd.AddCommand(newChangesCommand(runtime.Configuration))
+	cmd.AddCommand(newCompletionCommand())
 	cmd.AddCommand(newContextCommand(runtime.Configuration))
+	cmd.AddCommand(newDashboardCommand(runtime.Configuration))
 	cmd.AddCommand(newDemoCommand(runtime.Configuration, runtime.Assets))
 	cmd.AddCommand(newDeployCommand(runtime.Configuration))
 	cmd.AddCommand(newDeploymentsCommand(runtime.Configuration))
+	cmd.AddCommand(newDriftCommand(runtime.Configuration))
+	cmd.AddCommand(newFleetCommand(runtime.Configuration))
 	cmd.AddCommand(newNamespacesCommand(runtime.Configuration))
 	cmd.AddCommand(newOpsCommand())
 	cmd.AddCommand(newRolloutCommand(runtime.Configuration))
 	cmd.AddCommand(newEnvironmentsCommand(runtime.Configuration))
 	cmd.AddCommand(newSecretsCommand(runtime.Configuration))
 	cmd.AddCommand(newStatusCommand(runtime.Configuration))
+	cmd.AddCommand(newUpgradeCommand(runtime.Version))
 	cmd.AddCommand(newValidateCommand(runtime.Configuration))
 	cmd.AddCommand(newVersionCmd(runtime.Version))
+	registerFlagCompletions(cmd, runtime.Configuration)
 	cmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	cmd.PersistentFlags().StringVar(&contextName, "context", "", "The name of the context to use for this command. Does not change the current context.")
+	_ = cmd.RegisterFlagCompletionFunc("context", completeContextNames(runtime.Configuration))
+	cmd.PersistentFlags().StringVar(&colorMode, "color", style.ColorModeAuto, fmt.Sprintf("When to use color (%s, %s, or %s). Color is disabled in %q mode when output is not a terminal or when the NO_COLOR environment variable is set", style.ColorModeAuto, style.ColorModeAlways, style.ColorModeNever, style.ColorModeAuto))
+	_ = cmd.RegisterFlagCompletionFunc("color", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{style.ColorModeAuto, style.ColorModeAlways, style.ColorModeNever}, cobra.ShellCompDirectiveNoFileComp
+	})
+	addLoggingFlags(cmd)
+	addTraceFlags(cmd)
 
+	// Cobra only returns errors for invalid usage (e.g. unknown flags or invalid args). Commands exit via the ui package.
+	cmd.SilenceErrors = true
 	err := cmd.Execute()
 	if err != nil {
 		if verbose {
 			fmt.Printf("%#v\n", err)
 		}
-		os.Exit(1)
+		ui.ExitError(&ui.Error{Code: ui.ErrorCodeUsage, Message: err.Error(), Err: err})
 	}
 }
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"riser/pkg/completion"
+	"riser/pkg/config"
+	"riser/pkg/rc"
+	"riser/pkg/ui"
+	"sort"
+
+	"github.com/riser-platform/riser-server/pkg/sdk"
+	"github.com/spf13/cobra"
+)
+
+func newCompletionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion (bash|zsh|fish|powershell)",
+		Short: "Generates shell completion scripts",
+		Long:  "Generates shell completion scripts. Environment, namespace, app, and deployment names are completed in addition to commands and flags.",
+		Example: `  # bash (requires the bash-completion package)
+  source <(riser completion bash)
+  riser completion bash > /etc/bash_completion.d/riser
+
+  # zsh
+  riser completion zsh > "${fpath[1]}/_riser"
+
+  # fish
+  riser completion fish > ~/.config/fish/completions/riser.fish
+
+  # powershell
+  riser completion powershell | Out-String | Invoke-Expression`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.ExactValidArgs(1),
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			root := cmd.Root()
+			// The root command uses the path of the binary (e.g. /usr/local/bin/riser) which is not a valid function name
+			root.Use = filepath.Base(root.Name())
+			var err error
+			switch args[0] {
+			case "bash":
+				err = root.GenBashCompletion(os.Stdout)
+			case "zsh":
+				err = root.GenZshCompletion(os.Stdout)
+			case "fish":
+				err = root.GenFishCompletion(os.Stdout, true)
+			case "powershell":
+				err = root.GenPowerShellCompletion(os.Stdout)
+			}
+			ui.ExitIfErrorMsg(err, "Error generating completion")
+		},
+	}
+
+	return cmd
+}
+
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// completeArgs provides a completion for each positional argument. A nil completion disables completion for that argument.
+func completeArgs(completions ...completionFunc) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= len(completions) || completions[len(args)] == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completions[len(args)](cmd, args, toComplete)
+	}
+}
+
+// registerFlagCompletions adds completions for common flags to all commands
+func registerFlagCompletions(cmd *cobra.Command, runtimeConfig *rc.RuntimeConfiguration) {
+	flagCompletions := map[string]completionFunc{
+		"app":       completeAppNames(runtimeConfig),
+		"namespace": completeNamespaceNames(runtimeConfig),
+		// See addDeploymentNameFlag
+		"name": completeDeploymentNames(runtimeConfig),
+	}
+
+	for flagName, flagCompletion := range flagCompletions {
+		if cmd.LocalNonPersistentFlags().Lookup(flagName) != nil {
+			// Returns an error if the flag already has a completion, in which case the existing completion is kept
+			_ = cmd.RegisterFlagCompletionFunc(flagName, flagCompletion)
+		}
+	}
+
+	for _, subCmd := range cmd.Commands() {
+		registerFlagCompletions(subCmd, runtimeConfig)
+	}
+}
+
+func completeEnvironmentNames(runtimeConfig *rc.RuntimeConfiguration) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return getCompletions(runtimeConfig, "environments", func(riserClient *sdk.Client) ([]string, error) {
+			environments, err := riserClient.Environments.List()
+			if err != nil {
+				return nil, err
+			}
+			names := []string{}
+			for _, environment := range environments {
+				names = append(names, environment.Name)
+			}
+			return names, nil
+		})
+	}
+}
+
+func completeNamespaceNames(runtimeConfig *rc.RuntimeConfiguration) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return getCompletions(runtimeConfig, "namespaces", func(riserClient *sdk.Client) ([]string, error) {
+			namespaces, err := riserClient.Namespaces.List()
+			if err != nil {
+				return nil, err
+			}
+			names := []string{}
+			for _, namespace := range namespaces {
+				names = append(names, string(namespace.Name))
+			}
+			return names, nil
+		})
+	}
+}
+
+// completeAppNames completes app names in the namespace specified by the --namespace flag, or in all namespaces if the
+// command does not have a --namespace flag
+func completeAppNames(runtimeConfig *rc.RuntimeConfiguration) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		namespace := ""
+		if cmd.Flags().Lookup("namespace") != nil {
+			namespace = completionNamespace(cmd, runtimeConfig)
+		}
+		return getCompletions(runtimeConfig, "apps/"+namespace, func(riserClient *sdk.Client) ([]string, error) {
+			apps, err := riserClient.Apps.List()
+			if err != nil {
+				return nil, err
+			}
+			names := []string{}
+			for _, app := range apps {
+				if namespace == "" || string(app.Namespace) == namespace {
+					names = append(names, string(app.Name))
+				}
+			}
+			return names, nil
+		})
+	}
+}
+
+// completeDeploymentNames completes the deployment names for the app specified by the --app flag or by the app config
+func completeDeploymentNames(runtimeConfig *rc.RuntimeConfiguration) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		appName := config.SafeLoadDefaultAppName()
+		if appFlag := cmd.Flags().Lookup("app"); appFlag != nil && appFlag.Value.String() != "" {
+			appName = appFlag.Value.String()
+		}
+		if appName == "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		namespace := completionNamespace(cmd, runtimeConfig)
+		return getCompletions(runtimeConfig, fmt.Sprintf("deployments/%s/%s", namespace, appName), func(riserClient *sdk.Client) ([]string, error) {
+			status, err := riserClient.Apps.GetStatus(appName, namespace)
+			if err != nil {
+				return nil, err
+			}
+			names := []string{}
+			found := map[string]bool{}
+			for _, deployment := range status.Deployments {
+				if !found[deployment.DeploymentName] {
+					found[deployment.DeploymentName] = true
+					names = append(names, deployment.DeploymentName)
+				}
+			}
+			return names, nil
+		})
+	}
+}
+
+// completionNamespace returns the namespace for a completion. The context's default namespace is not applied to the
+// command being completed so it must be applied here.
+func completionNamespace(cmd *cobra.Command, runtimeConfig *rc.RuntimeConfiguration) string {
+	namespaceFlag := cmd.Flags().Lookup("namespace")
+	if namespaceFlag != nil && namespaceFlag.Changed {
+		return namespaceFlag.Value.String()
+	}
+
+	if currentContext, err := completionContext(runtimeConfig); err == nil && currentContext.DefaultNamespace != "" {
+		return config.SafeLoadDefaultAppNamespaceWithFallback(currentContext.DefaultNamespace)
+	}
+
+	return config.SafeLoadDefaultAppNamespace()
+}
+
+// completionContext returns the context for a completion. The --context flag is parsed after the root command's
+// PersistentPreRun has executed when completing, so the context override must be applied here.
+func completionContext(runtimeConfig *rc.RuntimeConfiguration) (*rc.Context, error) {
+	if contextName != "" {
+		return runtimeConfig.GetContext(contextName)
+	}
+	return runtimeConfig.CurrentContext()
+}
+
+// getCompletions returns cached completion values. Errors are not reported since there is no good way to display them
+// during completion.
+func getCompletions(runtimeConfig *rc.RuntimeConfiguration, resource string, fetch func(riserClient *sdk.Client) ([]string, error)) ([]string, cobra.ShellCompDirective) {
+	currentContext, err := completionContext(runtimeConfig)
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cacheDir, err := completion.DefaultDir()
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cacheKey := fmt.Sprintf("%s/%s/%s", currentContext.Name, currentContext.ServerURL, resource)
+	values, err := completion.NewCache(cacheDir, completion.DefaultTTL).Get(cacheKey, func() ([]string, error) {
+		riserClient, err := sdk.NewClient(currentContext.ServerURL, currentContext.Apikey)
+		if err != nil {
+			return nil, err
+		}
+		if !currentContext.IsSecure() {
+			riserClient.MakeInsecure()
+		}
+		return fetch(riserClient)
+	})
+	if err != nil {
+		cobra.CompDebugln(err.Error(), false)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	sort.Strings(values)
+	return values, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_completeArgs(t *testing.T) {
+	fakeCompletion := func(values ...string) completionFunc {
+		return func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return values, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+	completion := completeArgs(nil, fakeCompletion("dev", "prod"))
+
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{[]string{}, nil},
+		{[]string{"image:tag"}, []string{"dev", "prod"}},
+		{[]string{"image:tag", "dev"}, nil},
+	}
+
+	for _, tt := range tests {
+		result, directive := completion(&cobra.Command{}, tt.args, "")
+		assert.Equal(t, tt.expected, result, tt.args)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	}
+}
@@ -1,12 +1,18 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"riser/pkg/logger"
 	"riser/pkg/rc"
 	"riser/pkg/ui"
+	"riser/pkg/ui/style"
+	"time"
 
+	"github.com/riser-platform/riser-server/pkg/sdk"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func newContextCommand(config *rc.RuntimeConfiguration) *cobra.Command {
@@ -20,20 +26,33 @@ func newContextCommand(config *rc.RuntimeConfiguration) *cobra.Command {
 	cmd.AddCommand(newContextRemoveCommand(config))
 	cmd.AddCommand(newContextCurrentCommand(config))
 	cmd.AddCommand(newContextListCommand(config))
+	cmd.AddCommand(newContextShowCommand(config))
+	cmd.AddCommand(newContextRenameCommand(config))
+	cmd.AddCommand(newContextTestCommand(config))
 	return cmd
 }
 
 func newContextSaveCommand(config *rc.RuntimeConfiguration) *cobra.Command {
 	secure := true
+	var defaultNamespace string
+	var protectedEnvironments []string
 	cmd := &cobra.Command{
 		Use:   "save <contextName> <serverUrl> <apikey>",
 		Short: "Adds or updates a context",
-		Args:  cobra.ExactArgs(3),
+		Long: "Adds or updates a context. When updating a context, settings whose flags are not specified keep their current values. Freeze windows are configured by editing the context in the rc file (~/.riserrc), e.g.:\n\n" +
+			"  freezeWindows:\n  - name: holidays\n    environments: [\"prod*\"]\n    start: \"2020-12-24T00:00:00Z\"\n    end: \"2021-01-04T00:00:00Z\"",
+		Args: cobra.ExactArgs(3),
 		Run: func(cmd *cobra.Command, args []string) {
 			contextName := args[0]
-			ctx := &rc.Context{Name: contextName, ServerURL: args[1], Apikey: args[2], Secure: &secure}
-			config.SetContext(ctx)
-			err := rc.SaveRc(config)
+			ctx := &rc.Context{Name: contextName, ServerURL: args[1], Apikey: args[2], Secure: &secure, DefaultNamespace: defaultNamespace,
+				ProtectedEnvironments: protectedEnvironments}
+			err := rc.UpdateRc(config, func(latest *rc.RuntimeConfiguration) error {
+				if existing, err := latest.GetContext(contextName); err == nil {
+					keepContextSettings(ctx, existing, cmd.Flags())
+				}
+				latest.SetContext(ctx)
+				return nil
+			})
 			ui.ExitIfErrorMsg(err, "Error saving rc file")
 
 			logger.Log().Info(fmt.Sprintf("Context %q saved. Current context is now set to %q.", contextName, contextName))
@@ -41,20 +60,39 @@ func newContextSaveCommand(config *rc.RuntimeConfiguration) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&secure, "secure", true, "Set to false to skip TLS verification")
+	cmd.Flags().StringVar(&defaultNamespace, "default-namespace", "", "The namespace to use when a namespace is not specified by the --namespace flag or by the app config")
+	cmd.Flags().StringSliceVar(&protectedEnvironments, "protected-environments", nil, "Comma separated glob patterns (e.g. \"prod*\") for environments that require typing the environment name, or --confirm-environment, to confirm changes")
 
 	return cmd
 }
 
+// keepContextSettings copies settings from the existing context that were not specified by a flag so that updating a
+// context does not reset them. Settings without a flag (e.g. freeze windows) are always kept.
+func keepContextSettings(ctx *rc.Context, existing *rc.Context, flags *pflag.FlagSet) {
+	if !flags.Changed("secure") {
+		ctx.Secure = existing.Secure
+	}
+	if !flags.Changed("default-namespace") {
+		ctx.DefaultNamespace = existing.DefaultNamespace
+	}
+	if !flags.Changed("protected-environments") {
+		ctx.ProtectedEnvironments = existing.ProtectedEnvironments
+	}
+	ctx.DemoGatewayIP = existing.DemoGatewayIP
+	ctx.FreezeWindows = existing.FreezeWindows
+}
+
 func newContextRemoveCommand(config *rc.RuntimeConfiguration) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "remove <contextName>",
-		Args:  cobra.ExactArgs(1),
-		Short: "Removes a context",
+		Use:               "remove <contextName>",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Removes a context",
+		ValidArgsFunction: completeArgs(completeContextNames(config)),
 		Run: func(cmd *cobra.Command, args []string) {
-			err := config.RemoveContext(args[0])
+			err := rc.UpdateRc(config, func(latest *rc.RuntimeConfiguration) error {
+				return latest.RemoveContext(args[0])
+			})
 			ui.ExitIfErrorMsg(err, "Error removing context")
-			err = rc.SaveRc(config)
-			ui.ExitIfErrorMsg(err, "Error saving to rc file")
 		},
 	}
 
@@ -63,17 +101,21 @@ func newContextRemoveCommand(config *rc.RuntimeConfiguration) *cobra.Command {
 
 func newContextCurrentCommand(config *rc.RuntimeConfiguration) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "current [setCurrentContextName]",
-		Args:  cobra.MaximumNArgs(1),
-		Short: "Gets or sets the current context",
+		Use:               "current [setCurrentContextName]",
+		Args:              cobra.MaximumNArgs(1),
+		Short:             "Gets or sets the current context",
+		ValidArgsFunction: completeArgs(completeContextNames(config)),
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) > 0 && len(args[0]) > 0 {
-				err := config.SetCurrentContext(args[0])
+				err := rc.UpdateRc(config, func(latest *rc.RuntimeConfiguration) error {
+					return latest.SetCurrentContext(args[0])
+				})
 				ui.ExitIfErrorMsg(err, "unable to set context")
-				err = rc.SaveRc(config)
-				ui.ExitIfErrorMsg(err, "Error saving to rc file")
 
 				logger.Log().Info(fmt.Sprintf("Successfully loaded context \"%s\"\n", config.CurrentContextName))
+			} else if context, err := config.CurrentContext(); err == nil {
+				// Prefer the effective context so that the --context flag is honored
+				logger.Log().Info(context.Name)
 			} else {
 				logger.Log().Info(config.CurrentContextName)
 			}
@@ -88,16 +130,119 @@ func newContextListCommand(config *rc.RuntimeConfiguration) *cobra.Command {
 		Use:   "list",
 		Short: "Lists contexts",
 		Run: func(cmd *cobra.Command, args []string) {
-			contexts := config.GetContexts()
-			if len(contexts) == 0 {
-				logger.Log().Info("No contexts configured. Use \"riser context save\" to add a new context")
-			} else {
-				for _, context := range contexts {
-					logger.Log().Info(context.Name)
-				}
+			currentContextName := ""
+			if currentContext, err := config.CurrentContext(); err == nil {
+				currentContextName = currentContext.Name
+			}
+
+			ui.RenderView(newContextListView(config.GetContexts(), currentContextName))
+		},
+	}
+
+	addOutputFlag(cmd.Flags())
+
+	return cmd
+}
+
+func newContextShowCommand(config *rc.RuntimeConfiguration) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "show [contextName]",
+		Short:             "Shows the details of a context. Defaults to the current context.",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeArgs(completeContextNames(config)),
+		Run: func(cmd *cobra.Command, args []string) {
+			context := safeCurrentContextOrName(config, args)
+			// The current context may be unset when showing a context by name
+			currentContextName := ""
+			if currentContext, err := config.CurrentContext(); err == nil {
+				currentContextName = currentContext.Name
+			}
+
+			ui.RenderView(&contextShowView{context: newContextModel(context, currentContextName)})
+		},
+	}
+
+	addOutputFlag(cmd.Flags())
+
+	return cmd
+}
+
+func newContextRenameCommand(config *rc.RuntimeConfiguration) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "rename <contextName> <newContextName>",
+		Short:             "Renames a context",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeArgs(completeContextNames(config)),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := rc.UpdateRc(config, func(latest *rc.RuntimeConfiguration) error {
+				return latest.RenameContext(args[0], args[1])
+			})
+			ui.ExitIfErrorMsg(err, "Error renaming context")
+
+			logger.Log().Info(fmt.Sprintf("Context %q renamed to %q", args[0], args[1]))
+		},
+	}
+
+	return cmd
+}
+
+func newContextTestCommand(config *rc.RuntimeConfiguration) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "test [contextName]",
+		Short:             "Tests connectivity and authentication for a context. Defaults to the current context.",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeArgs(completeContextNames(config)),
+		Run: func(cmd *cobra.Command, args []string) {
+			context := safeCurrentContextOrName(config, args)
+			riserClient := getRiserClient(context)
+
+			start := time.Now()
+			environments, err := riserClient.Environments.List()
+			elapsed := time.Since(start)
+			if err != nil {
+				ui.ExitErrorMsg(formatContextTestError(context, err))
 			}
+
+			logger.Log().Info(fmt.Sprintf("%s Connected to %s in %s (%d environments available)",
+				style.Good("✔"), context.ServerURL, elapsed.Round(time.Millisecond), len(environments)))
 		},
 	}
 
 	return cmd
 }
+
+// formatContextTestError provides additional help for common connectivity and authentication errors
+func formatContextTestError(context *rc.Context, err error) string {
+	var clientErr *sdk.ClientError
+	if errors.As(err, &clientErr) {
+		switch clientErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return fmt.Sprintf("Authentication failed for context %q (HTTP %d). Check the context's apikey using \"riser context save\"", context.Name, clientErr.StatusCode)
+		}
+		return fmt.Sprintf("The server %s returned an error (HTTP %d): %s", context.ServerURL, clientErr.StatusCode, clientErr.Message)
+	}
+
+	return fmt.Sprintf("Unable to connect to %s for context %q: %s", context.ServerURL, context.Name, err)
+}
+
+// safeCurrentContextOrName returns the context named by the first arg if specified, otherwise the current context. Exits on any error.
+func safeCurrentContextOrName(config *rc.RuntimeConfiguration, args []string) *rc.Context {
+	if len(args) > 0 {
+		context, err := config.GetContext(args[0])
+		ui.ExitIfError(err)
+		return context
+	}
+
+	return safeCurrentContext(config)
+}
+
+// completeContextNames provides shell completion for context names
+func completeContextNames(config *rc.RuntimeConfiguration) completionFunc {
+	return func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		names := []string{}
+		for _, context := range config.GetContexts() {
+			names = append(names, context.Name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"riser/pkg/policy"
+	"riser/pkg/rc"
+	"riser/pkg/ui"
+	"riser/pkg/ui/table"
+	"strings"
+)
+
+// contextModel is the structured representation of a context. The apikey is intentionally omitted.
+type contextModel struct {
+	Name             string `json:"name"`
+	Current          bool   `json:"current"`
+	ServerURL        string `json:"serverUrl"`
+	Secure           bool   `json:"secure"`
+	DefaultNamespace string `json:"defaultNamespace,omitempty"`
+	// ProtectedEnvironments are patterns for environments that require confirmation before changes are applied
+	ProtectedEnvironments []string `json:"protectedEnvironments,omitempty"`
+	// FreezeWindows are periods of time during which deploys, rollouts, and deletes are not allowed
+	FreezeWindows []policy.FreezeWindow `json:"freezeWindows,omitempty"`
+}
+
+func newContextModel(context *rc.Context, currentContextName string) contextModel {
+	return contextModel{
+		Name:                  context.Name,
+		Current:               context.Name == currentContextName,
+		ServerURL:             context.ServerURL,
+		Secure:                context.IsSecure(),
+		DefaultNamespace:      context.DefaultNamespace,
+		ProtectedEnvironments: context.ProtectedEnvironments,
+		FreezeWindows:         context.FreezeWindows,
+	}
+}
+
+type contextListView struct {
+	contexts []contextModel
+}
+
+func newContextListView(contexts []rc.Context, currentContextName string) *contextListView {
+	view := &contextListView{contexts: []contextModel{}}
+	for idx := range contexts {
+		view.contexts = append(view.contexts, newContextModel(&contexts[idx], currentContextName))
+	}
+	return view
+}
+
+func (view *contextListView) RenderHuman(writer io.Writer) error {
+	if len(view.contexts) == 0 {
+		_, err := writer.Write([]byte("No contexts configured. Use \"riser context save\" to add a new context\n"))
+		return err
+	}
+
+	contextTable := table.Default().Header("Current", "Name", "Server URL", "Secure")
+	for _, context := range view.contexts {
+		current := ""
+		if context.Current {
+			current = "*"
+		}
+		contextTable.AddRow(current, context.Name, context.ServerURL, fmt.Sprintf("%t", context.Secure))
+	}
+
+	_, err := writer.Write([]byte(contextTable.String() + "\n"))
+	return err
+}
+
+func (view *contextListView) RenderJson(writer io.Writer) error {
+	return ui.RenderJson(view.contexts, writer)
+}
+
+type contextShowView struct {
+	context contextModel
+}
+
+func (view *contextShowView) RenderHuman(writer io.Writer) error {
+	outStr := ""
+	outStr += fmt.Sprintf("Name: %s\n", view.context.Name)
+	outStr += fmt.Sprintf("Current: %t\n", view.context.Current)
+	outStr += fmt.Sprintf("Server URL: %s\n", view.context.ServerURL)
+	outStr += fmt.Sprintf("Secure: %t\n", view.context.Secure)
+	if view.context.DefaultNamespace != "" {
+		outStr += fmt.Sprintf("Default Namespace: %s\n", view.context.DefaultNamespace)
+	}
+	if len(view.context.ProtectedEnvironments) > 0 {
+		outStr += fmt.Sprintf("Protected Environments: %s\n", strings.Join(view.context.ProtectedEnvironments, ", "))
+	}
+	if len(view.context.FreezeWindows) > 0 {
+		outStr += "Freeze Windows:\n"
+		for _, window := range view.context.FreezeWindows {
+			environments := "all environments"
+			if len(window.Environments) > 0 {
+				environments = strings.Join(window.Environments, ", ")
+			}
+			outStr += fmt.Sprintf("  %s: %s to %s (%s)\n", window.Name, window.Start, window.End, environments)
+		}
+	}
+	_, err := writer.Write([]byte(outStr))
+	return err
+}
+
+func (view *contextShowView) RenderJson(writer io.Writer) error {
+	return ui.RenderJson(view.context, writer)
+}
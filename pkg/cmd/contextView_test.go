@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"riser/pkg/rc"
+	"riser/pkg/util"
+	"testing"
+
+	"github.com/riser-platform/riser-server/pkg/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newContextListView(t *testing.T) {
+	contexts := []rc.Context{
+		{Name: "a", ServerURL: "https://a", Apikey: "secret"},
+		{Name: "b", ServerURL: "https://b", Secure: util.PtrBool(false), DefaultNamespace: "myns"},
+	}
+
+	result := newContextListView(contexts, "b")
+
+	require.Len(t, result.contexts, 2)
+	assert.Equal(t, contextModel{Name: "a", ServerURL: "https://a", Secure: true}, result.contexts[0])
+	assert.Equal(t, contextModel{Name: "b", Current: true, ServerURL: "https://b", Secure: false, DefaultNamespace: "myns"}, result.contexts[1])
+}
+
+func Test_contextListView_RenderJson_OmitsApikey(t *testing.T) {
+	view := newContextListView([]rc.Context{{Name: "a", Apikey: "secret"}}, "a")
+
+	var b bytes.Buffer
+	err := view.RenderJson(&b)
+
+	assert.NoError(t, err)
+	assert.NotContains(t, b.String(), "secret")
+}
+
+func Test_contextListView_RenderHuman_NoContexts(t *testing.T) {
+	view := newContextListView([]rc.Context{}, "")
+
+	var b bytes.Buffer
+	err := view.RenderHuman(&b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "No contexts configured. Use \"riser context save\" to add a new context\n", b.String())
+}
+
+func Test_formatContextTestError(t *testing.T) {
+	context := &rc.Context{Name: "a", ServerURL: "https://a"}
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{&sdk.ClientError{StatusCode: http.StatusUnauthorized}, `Authentication failed for context "a" (HTTP 401). Check the context's apikey using "riser context save"`},
+		{&sdk.ClientError{StatusCode: http.StatusInternalServerError, Message: "oops"}, "The server https://a returned an error (HTTP 500): oops"},
+		{errors.New("connection refused"), `Unable to connect to https://a for context "a": connection refused`},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, formatContextTestError(context, tt.err))
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"riser/pkg/policy"
+	"riser/pkg/rc"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_keepContextSettings(t *testing.T) {
+	secure := false
+	existing := &rc.Context{
+		Secure:                &secure,
+		DemoGatewayIP:         "10.0.0.1",
+		DefaultNamespace:      "myns",
+		ProtectedEnvironments: []string{"prod*"},
+		FreezeWindows:         []policy.FreezeWindow{{Name: "holidays"}},
+	}
+	ctx := &rc.Context{}
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("default-namespace", "", "")
+
+	keepContextSettings(ctx, existing, flags)
+
+	assert.Equal(t, existing, ctx)
+}
+
+func Test_keepContextSettings_FlagsChanged(t *testing.T) {
+	existingSecure := false
+	existing := &rc.Context{
+		Secure:                &existingSecure,
+		DefaultNamespace:      "myns",
+		ProtectedEnvironments: []string{"prod*"},
+	}
+	secure := true
+	ctx := &rc.Context{Secure: &secure}
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Bool("secure", true, "")
+	flags.String("default-namespace", "", "")
+	flags.StringSlice("protected-environments", nil, "")
+	require.NoError(t, flags.Parse([]string{"--secure=true", "--default-namespace=", "--protected-environments="}))
+
+	keepContextSettings(ctx, existing, flags)
+
+	assert.True(t, *ctx.Secure)
+	assert.Empty(t, ctx.DefaultNamespace)
+	assert.Empty(t, ctx.ProtectedEnvironments)
+}
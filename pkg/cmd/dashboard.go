@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"riser/pkg/policy"
+	"riser/pkg/rc"
+	"riser/pkg/ui"
+	uiterminal "riser/pkg/ui/terminal"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/riser-platform/riser-server/pkg/sdk"
+	"github.com/spf13/cobra"
+)
+
+const (
+	enterAlternateScreen = "\x1b[?1049h\x1b[?25l"
+	exitAlternateScreen  = "\x1b[?25h\x1b[?1049l"
+	clearScreen          = "\x1b[H\x1b[2J"
+)
+
+func newDashboardCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
+	var appName string
+	var namespace string
+	var appFilePath string
+	var refreshInterval time.Duration
+	cmd := &cobra.Command{
+		Use:     "ui",
+		Aliases: []string{"dashboard"},
+		Short:   "Interactive dashboard for an app",
+		Long: "Interactive dashboard for an app. Displays the status of the app's deployments across all environments, " +
+			"details for a deployment, and allows traffic to be shifted between revisions. Traffic cannot be shifted in protected or frozen environments. Use \"riser rollout\" instead.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !uiterminal.IsStdoutTerminal() {
+				ui.ExitErrorMsg("The dashboard requires a terminal. Use \"riser status\" instead.")
+			}
+
+			currentContext := safeCurrentContext(runtimeConfig)
+			riserClient := getRiserClient(currentContext)
+
+			// Fail fast before entering the alternate screen
+			data, err := fetchDashboardData(riserClient, appName, namespace, map[string]string{})
+			ui.ExitIfErrorMsg(err, "Error getting status")
+			repoPolicy, err := loadEnvironmentPolicy(filepath.Dir(appFilePath))
+			ui.ExitIfError(err)
+
+			dashboard := &dashboard{
+				currentContext:  currentContext,
+				repoPolicy:      repoPolicy,
+				riserClient:     riserClient,
+				model:           newDashboardModel(appName, namespace),
+				refreshInterval: refreshInterval,
+			}
+			dashboard.model.setData(data, time.Now())
+
+			ui.ExitIfError(dashboard.run())
+		},
+	}
+
+	addAppFlag(cmd.Flags(), &appName)
+	addNamespaceFlag(cmd.Flags(), &namespace)
+	addAppFilePathFlag(cmd.Flags(), &appFilePath)
+	cmd.Flags().DurationVar(&refreshInterval, "refresh-interval", 5*time.Second, "How often the dashboard is refreshed")
+
+	return cmd
+}
+
+type dashboard struct {
+	currentContext  *rc.Context
+	repoPolicy      *policy.Policy
+	riserClient     *sdk.Client
+	model           *dashboardModel
+	refreshInterval time.Duration
+}
+
+type dashboardRefreshResult struct {
+	data *dashboardData
+	err  error
+}
+
+// run runs the dashboard until the user quits. Errors from the riser server are displayed in the dashboard rather than
+// exiting, since exiting would not restore the terminal.
+func (d *dashboard) run() error {
+	stdio := terminal.Stdio{In: os.Stdin, Out: os.Stdout, Err: os.Stderr}
+	runeReader := terminal.NewRuneReader(stdio)
+	err := runeReader.SetTermMode()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = runeReader.RestoreTermMode() }()
+
+	fmt.Print(enterAlternateScreen)
+	defer fmt.Print(exitAlternateScreen)
+
+	keys := make(chan rune)
+	go func() {
+		for {
+			key, _, err := runeReader.ReadRune()
+			if err != nil {
+				close(keys)
+				return
+			}
+			keys <- key
+		}
+	}()
+
+	ticker := time.NewTicker(d.refreshInterval)
+	defer ticker.Stop()
+	refreshResults := make(chan dashboardRefreshResult)
+	rolloutResults := make(chan error)
+	refreshing := false
+	refresh := func() {
+		if refreshing {
+			return
+		}
+		refreshing = true
+		gatewayHosts := map[string]string{}
+		if d.model.data != nil {
+			gatewayHosts = d.model.data.gatewayHosts
+		}
+		go func() {
+			data, err := fetchDashboardData(d.riserClient, d.model.appName, d.model.namespace, gatewayHosts)
+			refreshResults <- dashboardRefreshResult{data, err}
+		}()
+	}
+
+	for {
+		d.draw()
+		select {
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			action := d.model.handleKey(key)
+			switch action.actionType {
+			case dashboardActionQuit:
+				return nil
+			case dashboardActionRefresh:
+				refresh()
+			case dashboardActionRollout:
+				// The user cannot be prompted to confirm a protected environment while the dashboard is running
+				err := checkEnvironmentUnguarded(d.currentContext, d.repoPolicy, action.environment, "riser rollout", time.Now())
+				if err != nil {
+					d.model.setError(err)
+					break
+				}
+				d.model.setMessage("Requesting rollout...")
+				go func() {
+					rolloutResults <- d.riserClient.Rollouts.Save(action.deploymentName, d.model.namespace, action.environment, action.trafficRules...)
+				}()
+			}
+		case <-ticker.C:
+			refresh()
+		case result := <-refreshResults:
+			refreshing = false
+			if result.err != nil {
+				d.model.setError(result.err)
+			} else {
+				d.model.setData(result.data, time.Now())
+			}
+		case err := <-rolloutResults:
+			if err != nil {
+				d.model.setError(err)
+			} else {
+				d.model.setMessage("Rollout requested")
+				refresh()
+			}
+		}
+	}
+}
+
+func (d *dashboard) draw() {
+	fmt.Print(clearScreen + d.model.render(uiterminal.Height()))
+}
+
+// fetchDashboardData gets the data for the dashboard. Gateway hosts are only fetched for environments that are not in
+// knownGatewayHosts since they rarely change.
+func fetchDashboardData(riserClient *sdk.Client, appName, namespace string, knownGatewayHosts map[string]string) (*dashboardData, error) {
+	app, err := riserClient.Apps.Get(appName, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	appStatus, err := riserClient.Apps.GetStatus(appName, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	gatewayHosts, err := getPublicGatewayHosts(riserClient, appStatus, knownGatewayHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dashboardData{app: app, status: appStatus, gatewayHosts: gatewayHosts}, nil
+}
+
+// getPublicGatewayHosts returns the public gateway host by environment name for each environment that the app is deployed
+// to. Gateway hosts are only fetched for environments that are not in knownGatewayHosts.
+func getPublicGatewayHosts(riserClient *sdk.Client, appStatus *model.AppStatus, knownGatewayHosts map[string]string) (map[string]string, error) {
+	gatewayHosts := map[string]string{}
+	for environmentName, host := range knownGatewayHosts {
+		gatewayHosts[environmentName] = host
+	}
+	for _, deployment := range appStatus.Deployments {
+		if _, ok := gatewayHosts[deployment.EnvironmentName]; ok {
+			continue
+		}
+		environmentConfig, err := riserClient.Environments.GetConfig(deployment.EnvironmentName)
+		if err != nil {
+			return nil, err
+		}
+		gatewayHosts[deployment.EnvironmentName] = environmentConfig.PublicGatewayHost
+	}
+
+	return gatewayHosts, nil
+}
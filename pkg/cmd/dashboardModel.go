@@ -0,0 +1,374 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"riser/pkg/status"
+	"riser/pkg/ui/style"
+	"riser/pkg/ui/table"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/riser-platform/riser-server/api/v1/model"
+)
+
+const (
+	dashboardScreenDeployments = iota
+	dashboardScreenDeployment
+	dashboardScreenTraffic
+)
+
+// dashboardSliderWidth is the number of characters in a traffic slider (5% per character)
+const dashboardSliderWidth = 20
+
+type dashboardActionType int
+
+const (
+	dashboardActionNone dashboardActionType = iota
+	dashboardActionQuit
+	dashboardActionRefresh
+	dashboardActionRollout
+)
+
+// dashboardAction is an action that the dashboard must perform as a result of a key press
+type dashboardAction struct {
+	actionType     dashboardActionType
+	deploymentName string
+	environment    string
+	trafficRules   []string
+}
+
+// dashboardData is the data that is refreshed by the dashboard
+type dashboardData struct {
+	app    *model.App
+	status *model.AppStatus
+	// gatewayHosts is the public gateway host by environment name
+	gatewayHosts map[string]string
+}
+
+type dashboardTrafficRule struct {
+	riserRevision int64
+	percent       int
+}
+
+// dashboardModel contains the state of the dashboard. It has no I/O so that it can be easily tested.
+type dashboardModel struct {
+	appName      string
+	namespace    string
+	data         *dashboardData
+	lastRefresh  time.Time
+	err          error
+	message      string
+	screen       int
+	selected     int
+	traffic      []dashboardTrafficRule
+	selectedRule int
+}
+
+func newDashboardModel(appName, namespace string) *dashboardModel {
+	return &dashboardModel{appName: appName, namespace: namespace}
+}
+
+// setData replaces the data after a refresh. The selected deployment is kept even if the order of deployments changes.
+func (m *dashboardModel) setData(data *dashboardData, refreshedAt time.Time) {
+	previous := m.selectedDeployment()
+	m.data = data
+	m.lastRefresh = refreshedAt
+	m.err = nil
+	if previous == nil {
+		return
+	}
+
+	for idx, deployment := range data.status.Deployments {
+		if deployment.DeploymentName == previous.DeploymentName && deployment.EnvironmentName == previous.EnvironmentName {
+			m.selected = idx
+			return
+		}
+	}
+
+	m.selected = 0
+	if m.screen != dashboardScreenDeployments {
+		m.screen = dashboardScreenDeployments
+		m.message = style.Warn(fmt.Sprintf("The deployment %q no longer exists in %q", previous.DeploymentName, previous.EnvironmentName))
+	}
+}
+
+func (m *dashboardModel) setError(err error) {
+	m.err = err
+}
+
+func (m *dashboardModel) setMessage(message string) {
+	m.message = message
+}
+
+func (m *dashboardModel) selectedDeployment() *model.DeploymentStatus {
+	if m.data == nil || m.selected >= len(m.data.status.Deployments) {
+		return nil
+	}
+	return &m.data.status.Deployments[m.selected]
+}
+
+// handleKey updates the state from a key press and returns an action for the dashboard to perform
+func (m *dashboardModel) handleKey(key rune) dashboardAction {
+	if key == terminal.KeyInterrupt {
+		return dashboardAction{actionType: dashboardActionQuit}
+	}
+
+	m.message = ""
+	switch m.screen {
+	case dashboardScreenDeployments:
+		return m.handleDeploymentsKey(key)
+	case dashboardScreenDeployment:
+		return m.handleDeploymentKey(key)
+	case dashboardScreenTraffic:
+		return m.handleTrafficKey(key)
+	}
+	return dashboardAction{}
+}
+
+func (m *dashboardModel) handleDeploymentsKey(key rune) dashboardAction {
+	switch key {
+	case 'q', terminal.KeyEscape:
+		return dashboardAction{actionType: dashboardActionQuit}
+	case 'r':
+		return dashboardAction{actionType: dashboardActionRefresh}
+	case terminal.KeyArrowUp, 'k':
+		if m.selected > 0 {
+			m.selected--
+		}
+	case terminal.KeyArrowDown, 'j':
+		if m.data != nil && m.selected < len(m.data.status.Deployments)-1 {
+			m.selected++
+		}
+	case terminal.KeyEnter, '\n':
+		if m.selectedDeployment() != nil {
+			m.screen = dashboardScreenDeployment
+		}
+	}
+	return dashboardAction{}
+}
+
+func (m *dashboardModel) handleDeploymentKey(key rune) dashboardAction {
+	switch key {
+	case 'q':
+		return dashboardAction{actionType: dashboardActionQuit}
+	case 'r':
+		return dashboardAction{actionType: dashboardActionRefresh}
+	case terminal.KeyEscape, terminal.KeyBackspace, terminal.KeyDelete:
+		m.screen = dashboardScreenDeployments
+	case 't':
+		m.startTrafficEdit()
+	}
+	return dashboardAction{}
+}
+
+func (m *dashboardModel) handleTrafficKey(key rune) dashboardAction {
+	switch key {
+	case terminal.KeyEscape:
+		m.screen = dashboardScreenDeployment
+		m.message = "Rollout cancelled"
+	case terminal.KeyArrowUp, 'k':
+		if m.selectedRule > 0 {
+			m.selectedRule--
+		}
+	case terminal.KeyArrowDown, 'j':
+		if m.selectedRule < len(m.traffic)-1 {
+			m.selectedRule++
+		}
+	case terminal.KeyArrowLeft, 'h':
+		m.adjustTraffic(-5)
+	case terminal.KeyArrowRight, 'l':
+		m.adjustTraffic(5)
+	case '-':
+		m.adjustTraffic(-1)
+	case '+', '=':
+		m.adjustTraffic(1)
+	case terminal.KeyEnter, '\n':
+		return m.saveTraffic()
+	}
+	return dashboardAction{}
+}
+
+func (m *dashboardModel) startTrafficEdit() {
+	deployment := m.selectedDeployment()
+	if deployment == nil {
+		return
+	}
+
+	m.traffic = []dashboardTrafficRule{}
+	for _, revision := range status.GetRevisionStatus(deployment, false) {
+		percent := 0
+		if revision.Traffic.Percent != nil {
+			percent = int(*revision.Traffic.Percent)
+		}
+		m.traffic = append(m.traffic, dashboardTrafficRule{riserRevision: revision.RiserRevision, percent: percent})
+	}
+	m.selectedRule = 0
+	m.screen = dashboardScreenTraffic
+}
+
+func (m *dashboardModel) adjustTraffic(delta int) {
+	if len(m.traffic) == 0 {
+		return
+	}
+	rule := &m.traffic[m.selectedRule]
+	rule.percent += delta
+	if rule.percent < 0 {
+		rule.percent = 0
+	} else if rule.percent > 100 {
+		rule.percent = 100
+	}
+}
+
+func (m *dashboardModel) trafficTotal() int {
+	total := 0
+	for _, rule := range m.traffic {
+		total += rule.percent
+	}
+	return total
+}
+
+func (m *dashboardModel) saveTraffic() dashboardAction {
+	if total := m.trafficTotal(); total != 100 {
+		m.message = style.Warn(fmt.Sprintf("Traffic must total 100%% (currently %d%%)", total))
+		return dashboardAction{}
+	}
+
+	rules := []string{}
+	for _, rule := range m.traffic {
+		if rule.percent > 0 {
+			rules = append(rules, fmt.Sprintf("r%d:%d", rule.riserRevision, rule.percent))
+		}
+	}
+
+	deployment := m.selectedDeployment()
+	m.screen = dashboardScreenDeployment
+	return dashboardAction{
+		actionType:     dashboardActionRollout,
+		deploymentName: deployment.DeploymentName,
+		environment:    deployment.EnvironmentName,
+		trafficRules:   rules,
+	}
+}
+
+// render renders the dashboard. The output is truncated to the height of the terminal.
+func (m *dashboardModel) render(height int) string {
+	lines := []string{
+		style.Emphasis(fmt.Sprintf("riser ui: %s (%s)", m.appName, m.namespace)) + style.Muted(m.formatLastRefresh()),
+		"",
+	}
+
+	body := ""
+	help := ""
+	switch {
+	case m.data == nil:
+		body = "Loading..."
+		help = "q: quit"
+	case m.screen == dashboardScreenDeployments:
+		body = m.renderDeployments()
+		help = "↑/↓: select  enter: describe  r: refresh  q: quit"
+	case m.screen == dashboardScreenDeployment:
+		body = m.renderDeployment()
+		help = "t: traffic  r: refresh  esc: back  q: quit"
+	case m.screen == dashboardScreenTraffic:
+		body = m.renderTraffic()
+		help = "↑/↓: select revision  ←/→: ±5%  -/+: ±1%  enter: save  esc: cancel"
+	}
+	lines = append(lines, strings.Split(strings.TrimRight(body, "\n"), "\n")...)
+
+	footer := []string{""}
+	if m.err != nil {
+		footer = append(footer, style.Bad(fmt.Sprintf("Error: %v", m.err)))
+	}
+	if m.message != "" {
+		footer = append(footer, m.message)
+	}
+	footer = append(footer, style.Muted(help))
+
+	if height > 0 && len(lines)+len(footer) > height {
+		maxBodyLines := height - len(footer)
+		if maxBodyLines < 0 {
+			maxBodyLines = 0
+		}
+		lines = lines[:maxBodyLines]
+	}
+
+	return strings.Join(append(lines, footer...), "\n")
+}
+
+func (m *dashboardModel) formatLastRefresh() string {
+	if m.lastRefresh.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("  refreshed %s", m.lastRefresh.Format("15:04:05"))
+}
+
+func (m *dashboardModel) renderDeployments() string {
+	if len(m.data.status.Deployments) == 0 {
+		return fmt.Sprintf("There are no deployments for the app %q. Use \"riser deploy\" to make your first deployment.", m.appName)
+	}
+
+	deploymentsTable := table.Default().Header("", "Deployment", "Env", "Traffic", "Rev", "Docker Tag", "Status", "Reason")
+	for idx := range m.data.status.Deployments {
+		deploymentStatus := m.data.status.Deployments[idx]
+		selected := ""
+		if idx == m.selected {
+			selected = ">"
+		}
+		first := true
+		for _, revision := range status.GetRevisionStatus(&deploymentStatus, true) {
+			row := []string{"", "", ""}
+			if first {
+				row = []string{selected, deploymentStatus.DeploymentName, deploymentStatus.EnvironmentName}
+			}
+			row = append(row,
+				formatTraffic(&revision.Traffic),
+				fmt.Sprintf("%d", revision.RiserRevision),
+				formatDockerTag(revision.DockerImage),
+				formatRevisionStatus(revision.RevisionStatus),
+				revision.RevisionStatusReason,
+			)
+			deploymentsTable.AddRow(row...)
+			first = false
+		}
+	}
+
+	return deploymentsTable.String()
+}
+
+func (m *dashboardModel) renderDeployment() string {
+	deployment := m.selectedDeployment()
+	view, err := newDeploymentsDescribeView(m.data.app, m.data.status, deployment.DeploymentName, deployment.EnvironmentName, m.data.gatewayHosts[deployment.EnvironmentName])
+	if err != nil {
+		return err.Error()
+	}
+
+	buffer := &bytes.Buffer{}
+	if err = view.RenderHuman(buffer); err != nil {
+		return err.Error()
+	}
+	return buffer.String()
+}
+
+func (m *dashboardModel) renderTraffic() string {
+	deployment := m.selectedDeployment()
+	outStr := fmt.Sprintf("Traffic for %q in %q\n\n", deployment.DeploymentName, deployment.EnvironmentName)
+	for idx, rule := range m.traffic {
+		selected := " "
+		if idx == m.selectedRule {
+			selected = ">"
+		}
+		filled := rule.percent * dashboardSliderWidth / 100
+		slider := style.Emphasis(strings.Repeat("█", filled)) + style.Muted(strings.Repeat("·", dashboardSliderWidth-filled))
+		outStr += fmt.Sprintf("%s r%-4d [%s] %3d%%\n", selected, rule.riserRevision, slider, rule.percent)
+	}
+
+	total := fmt.Sprintf("Total: %d%%", m.trafficTotal())
+	if m.trafficTotal() == 100 {
+		total = style.Good(total)
+	} else {
+		total = style.Warn(total)
+	}
+	return outStr + "\n" + total + "\n"
+}
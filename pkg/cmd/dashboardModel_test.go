@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"riser/pkg/util"
+	"testing"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDashboardData() *dashboardData {
+	return &dashboardData{
+		app: &model.App{Name: "myapp", Namespace: "apps"},
+		status: &model.AppStatus{
+			Environments: []model.EnvironmentStatus{{EnvironmentName: "dev", Healthy: true}, {EnvironmentName: "prod", Healthy: true}},
+			Deployments: []model.DeploymentStatus{
+				newTestDashboardDeployment("myapp", "dev"),
+				newTestDashboardDeployment("myapp", "prod"),
+			},
+		},
+		gatewayHosts: map[string]string{"dev": "dev.riser", "prod": "prod.riser"},
+	}
+}
+
+func newTestDashboardDeployment(deploymentName, environmentName string) model.DeploymentStatus {
+	return model.DeploymentStatus{
+		DeploymentName:  deploymentName,
+		EnvironmentName: environmentName,
+		RiserRevision:   2,
+		DeploymentStatusMutable: model.DeploymentStatusMutable{
+			ObservedRiserRevision:     2,
+			LatestCreatedRevisionName: "myapp-2",
+			Revisions: []model.DeploymentRevisionStatus{
+				{Name: "myapp-1", RiserRevision: 1, DockerImage: "myapp:v1", RevisionStatus: model.RevisionStatusReady},
+				{Name: "myapp-2", RiserRevision: 2, DockerImage: "myapp:v2", RevisionStatus: model.RevisionStatusReady},
+			},
+			Traffic: []model.DeploymentTrafficStatus{
+				{RevisionName: "myapp-1", Percent: util.PtrInt64(100)},
+			},
+		},
+	}
+}
+
+func Test_dashboardModel_Navigation(t *testing.T) {
+	m := newDashboardModel("myapp", "apps")
+	m.setData(newTestDashboardData(), time.Now())
+
+	m.handleKey(terminal.KeyArrowUp)
+	assert.Equal(t, 0, m.selected)
+	m.handleKey(terminal.KeyArrowDown)
+	m.handleKey('j')
+	assert.Equal(t, 1, m.selected)
+
+	m.handleKey(terminal.KeyEnter)
+	assert.Equal(t, dashboardScreenDeployment, m.screen)
+	assert.Contains(t, m.render(0), "Environment: prod")
+	assert.Contains(t, m.render(0), "External: https://myapp.apps.prod.riser")
+
+	m.handleKey(terminal.KeyEscape)
+	assert.Equal(t, dashboardScreenDeployments, m.screen)
+
+	assert.Equal(t, dashboardActionRefresh, m.handleKey('r').actionType)
+	assert.Equal(t, dashboardActionQuit, m.handleKey('q').actionType)
+	assert.Equal(t, dashboardActionQuit, m.handleKey(terminal.KeyInterrupt).actionType)
+}
+
+func Test_dashboardModel_Traffic(t *testing.T) {
+	m := newDashboardModel("myapp", "apps")
+	m.setData(newTestDashboardData(), time.Now())
+	m.handleKey(terminal.KeyEnter)
+	m.handleKey('t')
+
+	assert.Equal(t, dashboardScreenTraffic, m.screen)
+	assert.Equal(t, []dashboardTrafficRule{{2, 0}, {1, 100}}, m.traffic)
+
+	// Shift 10% to r2
+	m.handleKey(terminal.KeyArrowRight)
+	m.handleKey(terminal.KeyArrowRight)
+	assert.Contains(t, m.render(0), "Total: 110%")
+
+	action := m.handleKey(terminal.KeyEnter)
+	assert.Equal(t, dashboardActionNone, action.actionType)
+	assert.Equal(t, "Traffic must total 100% (currently 110%)", m.message)
+
+	m.handleKey(terminal.KeyArrowDown)
+	m.handleKey(terminal.KeyArrowLeft)
+	m.handleKey(terminal.KeyArrowLeft)
+	m.handleKey('-')
+	m.handleKey('+')
+	action = m.handleKey(terminal.KeyEnter)
+
+	assert.Equal(t, dashboardAction{
+		actionType:     dashboardActionRollout,
+		deploymentName: "myapp",
+		environment:    "dev",
+		trafficRules:   []string{"r2:10", "r1:90"},
+	}, action)
+	assert.Equal(t, dashboardScreenDeployment, m.screen)
+}
+
+func Test_dashboardModel_adjustTraffic_Bounds(t *testing.T) {
+	m := newDashboardModel("myapp", "apps")
+	m.traffic = []dashboardTrafficRule{{1, 2}, {2, 98}}
+
+	m.adjustTraffic(-5)
+	assert.Equal(t, 0, m.traffic[0].percent)
+
+	m.selectedRule = 1
+	m.adjustTraffic(5)
+	assert.Equal(t, 100, m.traffic[1].percent)
+}
+
+func Test_dashboardModel_setData_KeepsSelection(t *testing.T) {
+	m := newDashboardModel("myapp", "apps")
+	m.setData(newTestDashboardData(), time.Now())
+	m.handleKey(terminal.KeyArrowDown)
+	m.handleKey(terminal.KeyEnter)
+
+	reordered := newTestDashboardData()
+	reordered.status.Deployments[0], reordered.status.Deployments[1] = reordered.status.Deployments[1], reordered.status.Deployments[0]
+	m.setData(reordered, time.Now())
+
+	assert.Equal(t, 0, m.selected)
+	assert.Equal(t, "prod", m.selectedDeployment().EnvironmentName)
+	assert.Equal(t, dashboardScreenDeployment, m.screen)
+
+	removed := newTestDashboardData()
+	removed.status.Deployments = removed.status.Deployments[1:]
+	removed.status.Deployments[0].EnvironmentName = "staging"
+	m.setData(removed, time.Now())
+
+	assert.Equal(t, dashboardScreenDeployments, m.screen)
+	assert.Equal(t, `The deployment "myapp" no longer exists in "prod"`, m.message)
+}
+
+func Test_dashboardModel_render_TruncatesToHeight(t *testing.T) {
+	m := newDashboardModel("myapp", "apps")
+	m.setData(newTestDashboardData(), time.Date(2020, 9, 1, 12, 30, 0, 0, time.UTC))
+
+	result := m.render(4)
+
+	assert.Equal(t, "riser ui: myapp (apps)  refreshed 12:30:00\n\n\n↑/↓: select  enter: describe  r: refresh  q: quit", result)
+}
@@ -16,6 +16,7 @@ import (
 	"riser/pkg/ui"
 	"riser/pkg/ui/style"
 	"strings"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	validation "github.com/go-ozzo/ozzo-validation/v3"
@@ -74,13 +75,13 @@ curl a named deployment:
 				path = args[0]
 			}
 
-			if runtimeConfig.CurrentContextName != demoEnvironmentName {
-				ui.ExitErrorMsg(fmt.Sprintf("This command is only supported with the Riser context %q", demoEnvironmentName))
-			}
-
 			riserContext, err := runtimeConfig.CurrentContext()
 			ui.ExitIfError(err)
 
+			if riserContext.Name != demoEnvironmentName {
+				ui.ExitErrorMsg(fmt.Sprintf("This command is only supported with the Riser context %q", demoEnvironmentName))
+			}
+
 			if riserContext.DemoGatewayIP == "" {
 				ui.ExitErrorMsg("The Gateway IP has not been found. Use \"riser demo status\" to ensure that the demo is running properly.")
 			}
@@ -214,7 +215,8 @@ It's recommended that you use a deploy key (e.g. git@github.com:your/repo) with
 		}
 	}
 
-	logger.Log().Info("Installing demo")
+	installStart := time.Now()
+	logger.WithFields(logger.Fields{"environment": demoEnvironmentName, "kubeContext": strings.TrimSpace(string(kcOutput))}).Info("Installing demo")
 
 	deployment := infra.NewRiserDeployment(assets, config, gitUrl, demoEnvironmentName)
 
@@ -222,7 +224,8 @@ It's recommended that you use a deploy key (e.g. git@github.com:your/repo) with
 	err = deployment.Deploy()
 	ui.ExitIfError(err)
 
-	logger.Log().Info(style.Good("Installation Complete!"))
+	logger.WithFields(logger.Fields{"environment": demoEnvironmentName, "elapsedMs": time.Since(installStart).Milliseconds()}).
+		Info(style.Good("Installation Complete!"))
 	logger.Log().Info("Executing \"riser demo status\"...")
 
 	demoStatus(config)
@@ -277,11 +280,14 @@ func demoStatus(config *rc.RuntimeConfiguration) {
 
 	gatewayIp := strings.TrimSpace(ui.StripNewLines(ingressGatewayStep.State("stdout").(string)))
 
-	riserContext, err := config.CurrentContext()
-	ui.ExitIfError(err)
-	riserContext.DemoGatewayIP = gatewayIp
-	config.SetContext(riserContext)
-	err = rc.SaveRc(config)
+	err = rc.UpdateRc(config, func(latest *rc.RuntimeConfiguration) error {
+		riserContext, err := latest.GetContext(demoEnvironmentName)
+		if err != nil {
+			return err
+		}
+		riserContext.DemoGatewayIP = gatewayIp
+		return latest.UpdateContext(riserContext)
+	})
 	ui.ExitIfErrorMsg(err, "Error saving Riser config")
 
 	logger.Log().Info("\n" + style.Good("🚀 Everything checks out!") + "\n")
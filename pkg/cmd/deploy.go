@@ -4,15 +4,18 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
 	"riser/pkg/config"
 	"riser/pkg/deploy"
+	"riser/pkg/docker"
+	"riser/pkg/logger"
 	"riser/pkg/rc"
 	"riser/pkg/ui"
 	"riser/pkg/ui/style"
+	"strings"
 	"time"
 
 	"github.com/riser-platform/riser-server/api/v1/model"
-	"github.com/wzshiming/ctc"
 
 	"github.com/spf13/cobra"
 )
@@ -24,10 +27,13 @@ func newDeployCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
 	var manualRollout bool
 	var wait bool
 	var waitSeconds int
+	var skipPolicy []string
+	guardOptions := &environmentGuardOptions{}
 	cmd := &cobra.Command{
-		Use:   "deploy (docker tag) (targetEnvironment)",
-		Short: "Creates a new deployment or revision",
-		Args:  cobra.ExactArgs(2),
+		Use:               "deploy (docker tag) (targetEnvironment)",
+		ValidArgsFunction: completeArgs(nil, completeEnvironmentNames(runtimeConfig)),
+		Short:             "Creates a new deployment or revision",
+		Args:              cobra.ExactArgs(2),
 		Run: func(cmd *cobra.Command, args []string) {
 			currentContext := safeCurrentContext(runtimeConfig)
 			dockerTag := args[0]
@@ -38,6 +44,14 @@ func newDeployCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
 			app, err := config.LoadAppFromConfig(appFilePath)
 			ui.ExitIfErrorMsg(err, "Error loading app config")
 
+			ui.ExitIfError(validateDeployDockerTag(dockerTag))
+			ui.ExitIfError(checkPolicy(appFilePath, app, environment, dockerTag, skipPolicy))
+			if !dryRun {
+				_, err = guardEnvironment(currentContext, filepath.Dir(appFilePath), environment, guardOptions,
+					logger.Fields{"command": commandName(cmd), "deployment": deploymentName, "namespace": app.Namespace, "dockerTag": dockerTag})
+				ui.ExitIfError(err)
+			}
+
 			deployment := &model.SaveDeploymentRequest{
 				DeploymentMeta: model.DeploymentMeta{
 					Name:          deploymentName,
@@ -79,6 +93,8 @@ func newDeployCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
 	cmd.Flags().BoolVarP(&manualRollout, "manual-rollout", "m", false, "When set no traffic routes to the new deployment. Use \"riser rollout\" to manually route traffic")
 	cmd.Flags().BoolVar(&wait, "wait", false, "Blocks until the new deployment is ready to receive traffic or until --wait-seconds is reached. Cannot be used with --manual-rollout")
 	cmd.Flags().IntVar(&waitSeconds, "wait-seconds", 60, "Sets the number of seconds for --wait")
+	addSkipPolicyFlag(cmd.Flags(), &skipPolicy)
+	addEnvironmentGuardFlags(cmd.Flags(), guardOptions)
 	addOutputFlag(cmd.Flags())
 
 	return cmd
@@ -91,6 +107,19 @@ func validateNewDeployCommand(manualRollout, wait bool) error {
 	return nil
 }
 
+// validateDeployDockerTag returns an error if the tag is an image digest and warns if the tag is commonly reused for
+// different images. Digests are not supported since the riser server deploys the image as "(image):(tag)".
+func validateDeployDockerTag(tag string) error {
+	if docker.IsDigest(tag) || strings.Contains(tag, docker.DigestPrefix) {
+		return ui.NewError(ui.ErrorCodeUsage, fmt.Sprintf("Invalid docker tag %q: deploying an image digest is not supported by the riser server", tag))
+	}
+
+	if docker.IsMutableTag(tag) {
+		logger.Log().Warn(fmt.Sprintf("The tag %q may refer to a different image in the future. Deploy an immutable tag (e.g. a version or a git sha) so that the revision always runs the same image.", tag))
+	}
+	return nil
+}
+
 type newDeployView struct {
 	result        *model.SaveDeploymentResponse
 	manualRollout bool
@@ -106,15 +135,11 @@ func (view *newDeployView) RenderHuman(writer io.Writer) error {
 
 	if view.dryRun && view.result.DryRunCommits != nil {
 		for _, commit := range view.result.DryRunCommits {
-			outStr += ctc.ForegroundBrightCyan.String()
-			outStr += fmt.Sprintf("Commit: %s\n", commit.Message)
+			outStr += style.Emphasis(fmt.Sprintf("Commit: %s", commit.Message)) + "\n"
 			for _, file := range commit.Files {
-				outStr += ctc.ForegroundBrightWhite.String()
-				outStr += fmt.Sprintf("File: %s\n", file.Name)
-				outStr += ctc.ForegroundBrightBlack.String()
-				outStr += fmt.Sprintln(file.Contents)
+				outStr += style.Strong(fmt.Sprintf("File: %s", file.Name)) + "\n"
+				outStr += style.Muted(file.Contents) + "\n"
 			}
-			outStr += ctc.Reset.String()
 		}
 	}
 
@@ -23,3 +23,25 @@ func Test_validateNewDeployCommand(t *testing.T) {
 		assert.Equal(t, tt.expected, err)
 	}
 }
+
+func Test_validateDeployDockerTag(t *testing.T) {
+	tests := []struct {
+		tag string
+		err string
+	}{
+		{"v1", ""},
+		{"latest", ""},
+		{"@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", `Invalid docker tag "@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef": deploying an image digest is not supported by the riser server`},
+		{"sha256:abc", `Invalid docker tag "sha256:abc": deploying an image digest is not supported by the riser server`},
+	}
+
+	for _, tt := range tests {
+		err := validateDeployDockerTag(tt.tag)
+
+		if tt.err == "" {
+			assert.NoError(t, err, tt.tag)
+		} else {
+			assert.Equal(t, tt.err, err.Error())
+		}
+	}
+}
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
+	"riser/pkg/logger"
 	"riser/pkg/rc"
 	"riser/pkg/ui"
 
@@ -26,10 +28,11 @@ func newDeploymentsDescribeCommand(runtimeConfig *rc.RuntimeConfiguration) *cobr
 	var appName string
 	var namespace string
 	cmd := &cobra.Command{
-		Use:   "describe (deploymentName) (targetEnvironment)",
-		Short: "Display details of a deployment in a specific environment",
-		Long:  "Display details of a deployment in a specific environment. Use \"riser status\" to display a summary of all deployments in all environments for an app",
-		Args:  cobra.ExactArgs(2),
+		Use:               "describe (deploymentName) (targetEnvironment)",
+		ValidArgsFunction: completeArgs(completeDeploymentNames(runtimeConfig), completeEnvironmentNames(runtimeConfig)),
+		Short:             "Display details of a deployment in a specific environment",
+		Long:              "Display details of a deployment in a specific environment. Use \"riser status\" to display a summary of all deployments in all environments for an app",
+		Args:              cobra.ExactArgs(2),
 		Run: func(cmd *cobra.Command, args []string) {
 			deploymentName := args[0]
 			environmentName := args[1]
@@ -57,11 +60,14 @@ func newDeploymentsDescribeCommand(runtimeConfig *rc.RuntimeConfiguration) *cobr
 
 func newDeploymentsDeleteCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
 	var namespace string
+	var appFilePath string
 	noPrompt := false
+	guardOptions := &environmentGuardOptions{}
 	cmd := &cobra.Command{
-		Use:   "delete (deploymentName) (targetEnvironment)",
-		Short: "Permanentally deletes a deployment and all of its revisions in the specified environment",
-		Args:  cobra.ExactArgs(2),
+		Use:               "delete (deploymentName) (targetEnvironment)",
+		ValidArgsFunction: completeArgs(completeDeploymentNames(runtimeConfig), completeEnvironmentNames(runtimeConfig)),
+		Short:             "Permanentally deletes a deployment and all of its revisions in the specified environment",
+		Args:              cobra.ExactArgs(2),
 		Run: func(cmd *cobra.Command, args []string) {
 			currentContext := safeCurrentContext(runtimeConfig)
 			deleteConfirmed := false
@@ -71,8 +77,13 @@ func newDeploymentsDeleteCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.
 				Message: fmt.Sprintf("Are you sure you wish to delete the deployment %q in namespace %q in environment %q?", deploymentName, namespace, environmentName),
 			}
 
-			if !noPrompt {
-				err := survey.AskOne(prompt, &deleteConfirmed)
+			// Protected environments require the environment name to be typed instead
+			protectedConfirmed, err := guardEnvironment(currentContext, filepath.Dir(appFilePath), environmentName, guardOptions,
+				logger.Fields{"command": commandName(cmd), "deployment": deploymentName, "namespace": namespace})
+			ui.ExitIfError(err)
+
+			if !noPrompt && !protectedConfirmed {
+				err = survey.AskOne(prompt, &deleteConfirmed)
 				ui.ExitIfError(err)
 				if !deleteConfirmed {
 					return
@@ -87,8 +98,10 @@ func newDeploymentsDeleteCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.
 		},
 	}
 
-	cmd.Flags().BoolVar(&noPrompt, "no-prompt", false, "do not prompt for a confirmation")
+	cmd.Flags().BoolVar(&noPrompt, "no-prompt", false, "do not prompt for a confirmation. Protected environments also require --confirm-environment")
 	addNamespaceFlag(cmd.Flags(), &namespace)
+	addEnvironmentGuardFlags(cmd.Flags(), guardOptions)
+	addAppFilePathFlag(cmd.Flags(), &appFilePath)
 
 	return cmd
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"riser/pkg/status"
+	"riser/pkg/ui"
 	"riser/pkg/ui/style"
 	"riser/pkg/ui/table"
 
@@ -86,8 +87,39 @@ func (view *deploymentDescribeView) RenderHuman(writer io.Writer) error {
 	return err
 }
 
+type deploymentDescribeModel struct {
+	Name        string                             `json:"name"`
+	Namespace   string                             `json:"namespace"`
+	Environment *model.EnvironmentStatus           `json:"environment"`
+	App         *model.App                         `json:"app"`
+	Urls        deploymentDescribeUrlsModel        `json:"urls"`
+	Revisions   []status.RevisionStatusWithTraffic `json:"revisions"`
+}
+
+type deploymentDescribeUrlsModel struct {
+	External string `json:"external"`
+	Cluster  string `json:"cluster"`
+}
+
 func (view *deploymentDescribeView) RenderJson(writer io.Writer) error {
-	return nil
+	namespace := string(view.app.Namespace)
+	describeModel := &deploymentDescribeModel{
+		Name:        view.deploymentName,
+		Namespace:   namespace,
+		Environment: view.environmentStatus,
+		App:         view.app,
+		Urls: deploymentDescribeUrlsModel{
+			External: formatExternalUrl(view.deploymentName, namespace, view.externalHost),
+			Cluster:  formatClusterLocalUrl(view.deploymentName, namespace),
+		},
+		Revisions: []status.RevisionStatusWithTraffic{},
+	}
+
+	for _, deploymentStatus := range view.deploymentStatus {
+		describeModel.Revisions = append(describeModel.Revisions, status.GetRevisionStatus(&deploymentStatus, false)...)
+	}
+
+	return ui.RenderJson(describeModel, writer)
 }
 
 func formatExternalUrl(deploymentName, namespace, externalHost string) string {
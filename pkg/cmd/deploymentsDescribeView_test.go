@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
 
 	"github.com/riser-platform/riser-server/api/v1/model"
@@ -71,3 +73,41 @@ func Test_formatClusterLocalUrl(t *testing.T) {
 
 	assert.Equal(t, "http://mydep.apps.svc.cluster.local", result)
 }
+
+func Test_deploymentDescribeView_RenderJson(t *testing.T) {
+	app := &model.App{Name: "myapp", Namespace: model.NamespaceName("apps")}
+	appStatus := &model.AppStatus{
+		Environments: []model.EnvironmentStatus{
+			{EnvironmentName: "env1", Healthy: true},
+		},
+		Deployments: []model.DeploymentStatus{
+			{
+				DeploymentName:  "mydep",
+				EnvironmentName: "env1",
+				RiserRevision:   1,
+				DeploymentStatusMutable: model.DeploymentStatusMutable{
+					ObservedRiserRevision: 1,
+					Revisions: []model.DeploymentRevisionStatus{
+						{Name: "mydep-1", RiserRevision: 1, DockerImage: "foo:v1", RevisionStatus: model.RevisionStatusReady},
+					},
+				},
+			},
+		},
+	}
+	view, err := newDeploymentsDescribeView(app, appStatus, "mydep", "env1", "demo.riser")
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	err = view.RenderJson(&b)
+
+	assert.NoError(t, err)
+	result := &deploymentDescribeModel{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), result))
+	assert.Equal(t, "mydep", result.Name)
+	assert.Equal(t, "apps", result.Namespace)
+	assert.Equal(t, "env1", result.Environment.EnvironmentName)
+	assert.Equal(t, "https://mydep.apps.demo.riser", result.Urls.External)
+	assert.Equal(t, "http://mydep.apps.svc.cluster.local", result.Urls.Cluster)
+	require.Len(t, result.Revisions, 1)
+	assert.Equal(t, "foo:v1", result.Revisions[0].DockerImage)
+}
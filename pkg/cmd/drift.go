@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"riser/pkg/rc"
+	"riser/pkg/ui"
+	"strings"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/spf13/cobra"
+)
+
+func newDriftCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
+	var namespace string
+	var referenceEnvironment string
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: "Shows the docker tag running in each environment for every app",
+		Long: "Shows the docker tag running in each environment for every app. The tag is taken from the Ready revision with the most traffic. " +
+			"Use --reference to highlight tags that differ from the tag in another environment.",
+		Example: "  riser drift --reference prod\n  riser drift --reference prod -o csv > drift.csv",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			currentContext := safeCurrentContext(runtimeConfig)
+			riserClient := getRiserClient(currentContext)
+			environments, err := riserClient.Environments.List()
+			ui.ExitIfErrorMsg(err, "Error listing environments")
+			environmentNames := []string{}
+			referenceFound := false
+			for _, environment := range environments {
+				environmentNames = append(environmentNames, environment.Name)
+				referenceFound = referenceFound || environment.Name == referenceEnvironment
+			}
+			if referenceEnvironment != "" && !referenceFound {
+				ui.ExitError(ui.NewError(ui.ErrorCodeUsage, fmt.Sprintf("Unknown reference environment %q. Must be one of: %s", referenceEnvironment, strings.Join(environmentNames, ", "))))
+			}
+
+			apps, err := riserClient.Apps.List()
+			ui.ExitIfErrorMsg(err, "Error listing apps")
+			driftApps := []model.App{}
+			for _, app := range apps {
+				if namespace == "" || string(app.Namespace) == namespace {
+					driftApps = append(driftApps, app)
+				}
+			}
+
+			appStatuses := []*model.AppStatus{}
+			for _, result := range getAppStatuses(riserClient.Apps, driftApps, defaultStatusConcurrency) {
+				ui.ExitIfErrorMsg(result.err, fmt.Sprintf("Error getting status for app %q in namespace %q", result.app.Name, result.app.Namespace))
+				appStatuses = append(appStatuses, result.status)
+			}
+
+			ui.RenderView(newDriftView(environmentNames, referenceEnvironment, driftApps, appStatuses))
+		},
+	}
+
+	addNamespaceFilterFlag(cmd.Flags(), &namespace)
+	addOutputFlag(cmd.Flags())
+	cmd.Flags().StringVar(&referenceEnvironment, "reference", "", "Highlight tags that differ from the tag in this environment")
+	_ = cmd.RegisterFlagCompletionFunc("reference", completeEnvironmentNames(runtimeConfig))
+
+	return cmd
+}
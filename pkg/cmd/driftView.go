@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"riser/pkg/status"
+	"riser/pkg/ui"
+	"riser/pkg/ui/style"
+	"riser/pkg/ui/table"
+	"sort"
+	"strings"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+)
+
+type driftView struct {
+	model *driftModel
+}
+
+type driftModel struct {
+	Reference    string               `json:"reference,omitempty"`
+	Environments []string             `json:"environments"`
+	Deployments  []driftDeploymentRow `json:"deployments"`
+}
+
+type driftDeploymentRow struct {
+	Namespace  string `json:"namespace"`
+	App        string `json:"app"`
+	Deployment string `json:"deployment"`
+	// Environments contains the running revision by environment name. Environments without a Ready revision are omitted.
+	Environments map[string]driftCell `json:"environments"`
+}
+
+type driftCell struct {
+	RiserRevision int64  `json:"riserRevision"`
+	DockerImage   string `json:"dockerImage"`
+	Tag           string `json:"tag"`
+	// Drifted is true when the tag differs from the tag in the reference environment
+	Drifted bool `json:"drifted"`
+}
+
+// newDriftView creates a view from apps and their status. appStatuses must be in the same order as apps.
+func newDriftView(environmentNames []string, reference string, apps []model.App, appStatuses []*model.AppStatus) *driftView {
+	drift := &driftModel{
+		Reference:    reference,
+		Environments: append([]string{}, environmentNames...),
+		Deployments:  []driftDeploymentRow{},
+	}
+	sort.Strings(drift.Environments)
+
+	rows := map[string]*driftDeploymentRow{}
+	for idx, appStatus := range appStatuses {
+		for deploymentIdx := range appStatus.Deployments {
+			deploymentStatus := &appStatus.Deployments[deploymentIdx]
+			revision := runningRevision(deploymentStatus)
+			if revision == nil {
+				continue
+			}
+
+			key := fmt.Sprintf("%s/%s", apps[idx].Namespace, deploymentStatus.DeploymentName)
+			row, ok := rows[key]
+			if !ok {
+				row = &driftDeploymentRow{
+					Namespace:    string(apps[idx].Namespace),
+					App:          string(apps[idx].Name),
+					Deployment:   deploymentStatus.DeploymentName,
+					Environments: map[string]driftCell{},
+				}
+				rows[key] = row
+			}
+			row.Environments[deploymentStatus.EnvironmentName] = driftCell{
+				RiserRevision: revision.RiserRevision,
+				DockerImage:   revision.DockerImage,
+				Tag:           dockerTag(revision.DockerImage),
+			}
+		}
+	}
+
+	for _, row := range rows {
+		if referenceCell, ok := row.Environments[reference]; ok {
+			for environmentName, cell := range row.Environments {
+				cell.Drifted = cell.Tag != referenceCell.Tag
+				row.Environments[environmentName] = cell
+			}
+		}
+		drift.Deployments = append(drift.Deployments, *row)
+	}
+
+	sort.Slice(drift.Deployments, func(i, j int) bool {
+		a, b := drift.Deployments[i], drift.Deployments[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Deployment < b.Deployment
+	})
+
+	return &driftView{model: drift}
+}
+
+// runningRevision returns the Ready revision with the most traffic or nil if there are no Ready revisions. The latest
+// revision wins when revisions have the same traffic.
+func runningRevision(deploymentStatus *model.DeploymentStatus) *status.RevisionStatusWithTraffic {
+	var running *status.RevisionStatusWithTraffic
+	runningPercent := int64(-1)
+	// Revisions are sorted by the latest revision first
+	revisions := status.GetRevisionStatus(deploymentStatus, true)
+	for idx := range revisions {
+		if revisions[idx].RevisionStatus != model.RevisionStatusReady {
+			continue
+		}
+		percent := int64(0)
+		if revisions[idx].Traffic.Percent != nil {
+			percent = *revisions[idx].Traffic.Percent
+		}
+		if percent > runningPercent {
+			running = &revisions[idx]
+			runningPercent = percent
+		}
+	}
+
+	return running
+}
+
+// driftedEnvironments returns the names of the environments whose tag differs from the reference environment
+func (row *driftDeploymentRow) driftedEnvironments(environmentNames []string) []string {
+	drifted := []string{}
+	for _, environmentName := range environmentNames {
+		if cell, ok := row.Environments[environmentName]; ok && cell.Drifted {
+			drifted = append(drifted, environmentName)
+		}
+	}
+	return drifted
+}
+
+func (view *driftView) RenderHuman(writer io.Writer) error {
+	outStr := ""
+	if len(view.model.Deployments) == 0 {
+		outStr += "There are no deployments with a Ready revision.\n"
+	} else {
+		header := append([]string{"Namespace", "Deployment"}, view.model.Environments...)
+		driftTable := table.Default().Header(header...)
+		for _, row := range view.model.Deployments {
+			values := []string{row.Namespace, row.Deployment}
+			for _, environmentName := range view.model.Environments {
+				values = append(values, formatDriftCell(row.Environments[environmentName], environmentName == view.model.Reference))
+			}
+			driftTable.AddRow(values...)
+		}
+		outStr += driftTable.String() + "\n"
+		if view.model.Reference != "" {
+			outStr += style.Muted(fmt.Sprintf("\nHighlighted tags differ from the %q environment.\n", view.model.Reference))
+		}
+	}
+
+	_, err := writer.Write([]byte(outStr))
+	return err
+}
+
+func (view *driftView) RenderJson(writer io.Writer) error {
+	return ui.RenderJson(view.model, writer)
+}
+
+// RenderCsv renders a row for each deployment with a column for each environment's tag. When there is a reference
+// environment a final "Drifted" column lists the environments whose tag differs from the reference.
+func (view *driftView) RenderCsv(writer io.Writer) error {
+	header := append([]string{"Namespace", "App", "Deployment"}, view.model.Environments...)
+	if view.model.Reference != "" {
+		header = append(header, "Drifted")
+	}
+	records := [][]string{header}
+	for _, row := range view.model.Deployments {
+		record := []string{row.Namespace, row.App, row.Deployment}
+		for _, environmentName := range view.model.Environments {
+			record = append(record, row.Environments[environmentName].Tag)
+		}
+		if view.model.Reference != "" {
+			record = append(record, strings.Join(row.driftedEnvironments(view.model.Environments), " "))
+		}
+		records = append(records, record)
+	}
+
+	return ui.RenderCsv(records, writer)
+}
+
+func formatDriftCell(cell driftCell, isReference bool) string {
+	switch {
+	case cell.DockerImage == "":
+		return style.Muted("-")
+	case cell.Drifted:
+		return style.Warn(formatDockerTag(cell.DockerImage))
+	case isReference:
+		return style.Emphasis(formatDockerTag(cell.DockerImage))
+	default:
+		return formatDockerTag(cell.DockerImage)
+	}
+}
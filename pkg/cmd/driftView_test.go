@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"riser/pkg/util"
+	"testing"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_runningRevision(t *testing.T) {
+	deploymentStatus := &model.DeploymentStatus{
+		RiserRevision: 3,
+		DeploymentStatusMutable: model.DeploymentStatusMutable{
+			ObservedRiserRevision:     3,
+			LatestCreatedRevisionName: "rev3",
+			Revisions: []model.DeploymentRevisionStatus{
+				{Name: "rev1", RiserRevision: 1, DockerImage: "app:v1", RevisionStatus: model.RevisionStatusReady},
+				{Name: "rev2", RiserRevision: 2, DockerImage: "app:v2", RevisionStatus: model.RevisionStatusReady},
+				{Name: "rev3", RiserRevision: 3, DockerImage: "app:v3", RevisionStatus: model.RevisionStatusUnhealthy},
+			},
+			Traffic: []model.DeploymentTrafficStatus{
+				{RevisionName: "rev1", Percent: util.PtrInt64(60)},
+				{RevisionName: "rev2", Percent: util.PtrInt64(40)},
+			},
+		},
+	}
+
+	result := runningRevision(deploymentStatus)
+
+	require.NotNil(t, result)
+	assert.Equal(t, "app:v1", result.DockerImage)
+
+	// No Ready revisions
+	assert.Nil(t, runningRevision(&model.DeploymentStatus{RiserRevision: 1}))
+}
+
+func Test_driftView(t *testing.T) {
+	readyStatus := func(environmentName, dockerImage string) model.DeploymentStatus {
+		return model.DeploymentStatus{
+			DeploymentName:  "web",
+			EnvironmentName: environmentName,
+			RiserRevision:   1,
+			DeploymentStatusMutable: model.DeploymentStatusMutable{
+				ObservedRiserRevision:     1,
+				LatestCreatedRevisionName: "rev1",
+				Revisions:                 []model.DeploymentRevisionStatus{{Name: "rev1", RiserRevision: 1, DockerImage: dockerImage, RevisionStatus: model.RevisionStatusReady}},
+				Traffic:                   []model.DeploymentTrafficStatus{{RevisionName: "rev1", Percent: util.PtrInt64(100)}},
+			},
+		}
+	}
+	apps := []model.App{{Name: "web", Namespace: "apps"}}
+	appStatuses := []*model.AppStatus{
+		{Deployments: []model.DeploymentStatus{readyStatus("prod", "web:v1"), readyStatus("dev", "web:v2"), readyStatus("staging", "web:v1")}},
+	}
+
+	view := newDriftView([]string{"staging", "prod", "dev", "test"}, "prod", apps, appStatuses)
+
+	var jsonBuffer bytes.Buffer
+	require.NoError(t, view.RenderJson(&jsonBuffer))
+	result := &driftModel{}
+	require.NoError(t, json.Unmarshal(jsonBuffer.Bytes(), result))
+	assert.Equal(t, []string{"dev", "prod", "staging", "test"}, result.Environments)
+	require.Len(t, result.Deployments, 1)
+	assert.Equal(t, map[string]driftCell{
+		"dev":     {RiserRevision: 1, DockerImage: "web:v2", Tag: "v2", Drifted: true},
+		"prod":    {RiserRevision: 1, DockerImage: "web:v1", Tag: "v1"},
+		"staging": {RiserRevision: 1, DockerImage: "web:v1", Tag: "v1"},
+	}, result.Deployments[0].Environments)
+
+	var csvBuffer bytes.Buffer
+	require.NoError(t, view.RenderCsv(&csvBuffer))
+	assert.Equal(t, "Namespace,App,Deployment,dev,prod,staging,test,Drifted\napps,web,web,v2,v1,v1,,dev\n", csvBuffer.String())
+}
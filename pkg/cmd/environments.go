@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"riser/pkg/rc"
 	"riser/pkg/ui"
 
@@ -15,14 +16,19 @@ func newEnvironmentsCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Comma
 	}
 
 	cmd.AddCommand(newEnvironmentsListCommand(runtimeConfig))
+	cmd.AddCommand(newEnvironmentsDescribeCommand(runtimeConfig))
+	cmd.AddCommand(newEnvironmentsConfigCommand(runtimeConfig))
 
 	return cmd
 }
 
 func newEnvironmentsListCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
+	options := &listOptions{}
+	showHealth := false
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "Lists all available environments",
+		Long:  "Lists all available environments. Use --health to include the health of each environment. The health is gathered from the status of every app since the riser server does not report the health of an environment directly.",
 		Run: func(cmd *cobra.Command, args []string) {
 			currentContext := safeCurrentContext(runtimeConfig)
 			riserClient := getRiserClient(currentContext)
@@ -30,16 +36,68 @@ func newEnvironmentsListCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.C
 			ui.ExitIfError(err)
 
 			view := &ui.BasicTableView{}
-			view.Header("Name")
+			if showHealth {
+				summary, err := getEnvironmentsSummary(riserClient.Apps)
+				ui.ExitIfErrorMsg(err, "Error getting environment health")
 
-			for _, environment := range environments {
-				view.AddRow(environment.Name)
+				view.Header("Name", "Health", "Reason")
+				for _, environment := range environments {
+					health, reason := summary.healthStatus(environment.Name)
+					view.AddRow(environment.Name, health, reason)
+				}
+			} else {
+				view.Header("Name")
+				for _, environment := range environments {
+					view.AddRow(environment.Name)
+				}
 			}
 
+			ui.ExitIfError(options.apply(view))
 			ui.RenderView(view)
 		},
 	}
 
+	cmd.Flags().BoolVar(&showHealth, "health", false, "Include the health of each environment. The health is gathered from the status of every app, which requires a request for each app")
+	addListFlags(cmd.Flags(), options)
+	addOutputFlag(cmd.Flags())
+
+	return cmd
+}
+
+func newEnvironmentsDescribeCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "describe (environment name)",
+		Short:             "Describes an environment and every deployment in it",
+		Long:              "Describes an environment and every deployment in it, including the environment's config and health. The time that the controller last reported is not shown since the riser server does not report it.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeEnvironmentNames(runtimeConfig)),
+		Run: func(cmd *cobra.Command, args []string) {
+			environmentName := args[0]
+			currentContext := safeCurrentContext(runtimeConfig)
+			riserClient := getRiserClient(currentContext)
+
+			environments, err := riserClient.Environments.List()
+			ui.ExitIfError(err)
+			found := false
+			for _, environment := range environments {
+				if environment.Name == environmentName {
+					found = true
+				}
+			}
+			if !found {
+				ui.ExitError(ui.NewError(ui.ErrorCodeNotFound, fmt.Sprintf("The environment %q does not exist", environmentName)))
+			}
+
+			config, err := riserClient.Environments.GetConfig(environmentName)
+			ui.ExitIfErrorMsg(err, "Error getting environment config")
+
+			summary, err := getEnvironmentsSummary(riserClient.Apps)
+			ui.ExitIfErrorMsg(err, "Error getting environment health")
+
+			ui.RenderView(&environmentsDescribeView{environmentName: environmentName, config: config, summary: summary})
+		},
+	}
+
 	addOutputFlag(cmd.Flags())
 
 	return cmd
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"riser/pkg/logger"
+	"riser/pkg/rc"
+	"riser/pkg/ui"
+	"riser/pkg/ui/style"
+	"sort"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+	"github.com/go-ozzo/ozzo-validation/v3/is"
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/spf13/cobra"
+)
+
+// environmentConfigKey is a setting in the environment config that can be managed by the CLI
+type environmentConfigKey struct {
+	description string
+	get         func(config *model.EnvironmentConfig) string
+	// set validates the value and sets it
+	set func(config *model.EnvironmentConfig, value string) error
+}
+
+var environmentConfigKeys = map[string]environmentConfigKey{
+	"publicGatewayHost": {
+		description: "The host name of the public gateway (e.g. \"prod.example.com\"). Apps are exposed at https://(deployment).(namespace).(publicGatewayHost)",
+		get: func(config *model.EnvironmentConfig) string {
+			return config.PublicGatewayHost
+		},
+		set: func(config *model.EnvironmentConfig, value string) error {
+			err := validation.Validate(value, validation.Required, is.DNSName)
+			if err != nil {
+				return err
+			}
+			config.PublicGatewayHost = value
+			return nil
+		},
+	},
+	"sealedSecretCert": {
+		description: "The path to the PEM encoded certificate used to seal secrets. This is normally set by the riser controller.",
+		get: func(config *model.EnvironmentConfig) string {
+			return formatSealedSecretCert(config.SealedSecretCert)
+		},
+		set: func(config *model.EnvironmentConfig, value string) error {
+			certBytes, err := ioutil.ReadFile(expandTildeInPath(value))
+			if err != nil {
+				return err
+			}
+			if _, err = parsePEMCertificate(certBytes); err != nil {
+				return err
+			}
+			config.SealedSecretCert = certBytes
+			return nil
+		},
+	},
+}
+
+func newEnvironmentsConfigCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Commands for managing the configuration of an environment",
+		Long:  "Commands for managing the configuration of an environment.\n\nKeys:\n" + formatEnvironmentConfigKeys(),
+	}
+
+	cmd.AddCommand(newEnvironmentsConfigGetCommand(runtimeConfig))
+	cmd.AddCommand(newEnvironmentsConfigSetCommand(runtimeConfig))
+
+	return cmd
+}
+
+func newEnvironmentsConfigGetCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "get (environment name) [key]",
+		Short:             "Gets the configuration for an environment",
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completeArgs(completeEnvironmentNames(runtimeConfig), completeEnvironmentConfigKeys),
+		Run: func(cmd *cobra.Command, args []string) {
+			keys := environmentConfigKeyNames()
+			if len(args) > 1 {
+				if _, ok := environmentConfigKeys[args[1]]; !ok {
+					ui.ExitError(unknownEnvironmentConfigKeyError(args[1]))
+				}
+				keys = []string{args[1]}
+			}
+
+			currentContext := safeCurrentContext(runtimeConfig)
+			riserClient := getRiserClient(currentContext)
+			config, err := riserClient.Environments.GetConfig(args[0])
+			ui.ExitIfErrorMsg(err, "Error getting environment config")
+
+			view := &ui.BasicTableView{}
+			view.Header("Key", "Value")
+			for _, key := range keys {
+				view.AddRow(key, environmentConfigKeys[key].get(config))
+			}
+
+			ui.RenderView(view)
+		},
+	}
+
+	addOutputFlag(cmd.Flags())
+
+	return cmd
+}
+
+func newEnvironmentsConfigSetCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
+	var appFilePath string
+	dryRun := false
+	confirmEnvironment := ""
+	cmd := &cobra.Command{
+		Use:   "set (environment name) (key=value)...",
+		Short: "Sets configuration for an environment",
+		Long: "Sets configuration for an environment. Confirmation is required for environments that match the context's " +
+			"protected environments (see \"riser context save --protected-environments\") or the protected environments in the policy file. Use --confirm-environment to confirm without prompting.\n\nKeys:\n" + formatEnvironmentConfigKeys(),
+		Example:           "  riser environments config set prod publicGatewayHost=prod.example.com --dry-run",
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completeArgs(completeEnvironmentNames(runtimeConfig)),
+		Run: func(cmd *cobra.Command, args []string) {
+			environmentName := args[0]
+			changes, err := parseEnvironmentConfigChanges(args[1:])
+			ui.ExitIfError(err)
+
+			currentContext := safeCurrentContext(runtimeConfig)
+			riserClient := getRiserClient(currentContext)
+			existing, err := riserClient.Environments.GetConfig(environmentName)
+			ui.ExitIfErrorMsg(err, "Error getting environment config")
+
+			changedKeys := []string{}
+			for _, key := range sortedEnvironmentConfigChangeKeys(changes) {
+				before := environmentConfigKeys[key].get(existing)
+				after := environmentConfigKeys[key].get(changes)
+				if before != after {
+					changedKeys = append(changedKeys, key)
+					logger.Log().Info(fmt.Sprintf("%s: %s -> %s", style.Emphasis(key), formatEnvironmentConfigValue(before), after))
+				}
+			}
+
+			if len(changedKeys) == 0 {
+				logger.Log().Info(fmt.Sprintf("The configuration for the environment %q is already up to date", environmentName))
+				return
+			}
+
+			if dryRun {
+				logger.Log().Info("Dry run: the configuration was not changed")
+				return
+			}
+
+			repoPolicy, err := loadEnvironmentPolicy(filepath.Dir(appFilePath))
+			ui.ExitIfError(err)
+			_, err = confirmProtectedEnvironment(currentContext, repoPolicy, environmentName, confirmEnvironment)
+			ui.ExitIfError(err)
+
+			// Empty values are ignored by the server so only the changes are sent
+			err = riserClient.Environments.SetConfig(environmentName, changes)
+			ui.ExitIfErrorMsg(err, "Error setting environment config")
+			logger.Log().Info(fmt.Sprintf("Configuration for the environment %q updated", environmentName))
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate and show the changes without applying them")
+	addConfirmEnvironmentFlag(cmd.Flags(), &confirmEnvironment)
+	addAppFilePathFlag(cmd.Flags(), &appFilePath)
+
+	return cmd
+}
+
+// parseEnvironmentConfigChanges parses and validates key=value pairs into an environment config containing only the changes
+func parseEnvironmentConfigChanges(args []string) (*model.EnvironmentConfig, error) {
+	changes := &model.EnvironmentConfig{}
+	for _, arg := range args {
+		keyValue := strings.SplitN(arg, "=", 2)
+		if len(keyValue) != 2 {
+			return nil, ui.NewError(ui.ErrorCodeUsage, fmt.Sprintf("Invalid argument %q: must be in the form key=value", arg))
+		}
+
+		key, ok := environmentConfigKeys[keyValue[0]]
+		if !ok {
+			return nil, unknownEnvironmentConfigKeyError(keyValue[0])
+		}
+
+		if err := key.set(changes, keyValue[1]); err != nil {
+			return nil, ui.NewError(ui.ErrorCodeValidation, fmt.Sprintf("Invalid value for %q: %v", keyValue[0], err))
+		}
+	}
+
+	return changes, nil
+}
+
+// sortedEnvironmentConfigChangeKeys returns the keys that have a value in the changes
+func sortedEnvironmentConfigChangeKeys(changes *model.EnvironmentConfig) []string {
+	keys := []string{}
+	for _, key := range environmentConfigKeyNames() {
+		if environmentConfigKeys[key].get(changes) != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func parsePEMCertificate(certBytes []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certBytes)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("must be a PEM encoded certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func formatSealedSecretCert(certBytes []byte) string {
+	if len(certBytes) == 0 {
+		return ""
+	}
+	cert, err := parsePEMCertificate(certBytes)
+	if err != nil {
+		return fmt.Sprintf("invalid certificate: %v", err)
+	}
+	return fmt.Sprintf("%s (expires %s)", cert.Subject.String(), cert.NotAfter.UTC().Format("2006-01-02"))
+}
+
+func formatEnvironmentConfigValue(value string) string {
+	if value == "" {
+		return "(not set)"
+	}
+	return value
+}
+
+func unknownEnvironmentConfigKeyError(key string) *ui.Error {
+	return ui.NewError(ui.ErrorCodeUsage, fmt.Sprintf("Unknown key %q. Must be one of: %s", key, strings.Join(environmentConfigKeyNames(), ", ")))
+}
+
+func environmentConfigKeyNames() []string {
+	keys := []string{}
+	for key := range environmentConfigKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatEnvironmentConfigKeys() string {
+	outStr := ""
+	for _, key := range environmentConfigKeyNames() {
+		outStr += fmt.Sprintf("  %s: %s\n", key, environmentConfigKeys[key].description)
+	}
+	return outStr
+}
+
+func completeEnvironmentConfigKeys(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return environmentConfigKeyNames(), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"riser/pkg/ui"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseEnvironmentConfigChanges(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+		code     ui.ErrorCode
+		err      string
+	}{
+		{[]string{"publicGatewayHost=prod.example.com"}, "prod.example.com", "", ""},
+		{[]string{"publicGatewayHost"}, "", ui.ErrorCodeUsage, `Invalid argument "publicGatewayHost": must be in the form key=value`},
+		{[]string{"foo=bar"}, "", ui.ErrorCodeUsage, `Unknown key "foo". Must be one of: publicGatewayHost, sealedSecretCert`},
+		{[]string{"publicGatewayHost=https://prod.example.com"}, "", ui.ErrorCodeValidation, `Invalid value for "publicGatewayHost": must be a valid DNS name`},
+		{[]string{"publicGatewayHost="}, "", ui.ErrorCodeValidation, `Invalid value for "publicGatewayHost": cannot be blank`},
+	}
+
+	for _, tt := range tests {
+		result, err := parseEnvironmentConfigChanges(tt.args)
+
+		if tt.err != "" {
+			require.Error(t, err)
+			assert.Equal(t, tt.err, err.Error())
+			assert.Equal(t, tt.code, ui.ClassifyError(err).Code)
+		} else {
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.PublicGatewayHost)
+			assert.Equal(t, []string{"publicGatewayHost"}, sortedEnvironmentConfigChangeKeys(result))
+		}
+	}
+}
+
+func Test_parseEnvironmentConfigChanges_SealedSecretCert(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "riser-env-config")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	certPath := filepath.Join(tmpDir, "cert.pem")
+	require.NoError(t, ioutil.WriteFile(certPath, createTestCertificate(t), 0600))
+	invalidPath := filepath.Join(tmpDir, "invalid.pem")
+	require.NoError(t, ioutil.WriteFile(invalidPath, []byte("nope"), 0600))
+
+	result, err := parseEnvironmentConfigChanges([]string{"sealedSecretCert=" + certPath})
+
+	require.NoError(t, err)
+	assert.Equal(t, "CN=sealed-secrets (expires 2030-01-02)", formatSealedSecretCert(result.SealedSecretCert))
+
+	_, err = parseEnvironmentConfigChanges([]string{"sealedSecretCert=" + invalidPath})
+
+	assert.Equal(t, `Invalid value for "sealedSecretCert": must be a PEM encoded certificate`, err.Error())
+}
+
+func createTestCertificate(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sealed-secrets"},
+		NotBefore:    time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"riser/pkg/logger"
+	"riser/pkg/status"
+	"riser/pkg/ui"
+	"riser/pkg/ui/style"
+	"riser/pkg/ui/table"
+	"sort"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/riser-platform/riser-server/pkg/sdk"
+)
+
+const (
+	environmentHealthHealthy   = "Healthy"
+	environmentHealthUnhealthy = "Unhealthy"
+	environmentHealthUnknown   = "Unknown"
+)
+
+// environmentsSummary contains the health and deployments of each environment. The riser API only reports the health of
+// an environment as part of an app's status, so the summary is gathered from the status of every app.
+type environmentsSummary struct {
+	health      map[string]model.EnvironmentStatus
+	deployments map[string][]environmentDeployment
+	// statusErrors is the number of apps whose status could not be retrieved
+	statusErrors int
+}
+
+type environmentDeployment struct {
+	app    model.App
+	status model.DeploymentStatus
+}
+
+// getEnvironmentsSummary gathers the summary from the status of every app. Apps whose status cannot be retrieved are
+// skipped so that a single failure does not prevent reporting on the other apps.
+func getEnvironmentsSummary(appsClient sdk.AppsClient) (*environmentsSummary, error) {
+	apps, err := appsClient.List()
+	if err != nil {
+		return nil, err
+	}
+
+	statusApps := []model.App{}
+	appStatuses := []*model.AppStatus{}
+	statusErrors := 0
+	for _, result := range getAppStatuses(appsClient, apps, defaultStatusConcurrency) {
+		if result.err != nil {
+			logger.Log().Verbose(fmt.Sprintf("Error getting status for app %q in namespace %q: %v", result.app.Name, result.app.Namespace, result.err))
+			statusErrors++
+			continue
+		}
+		statusApps = append(statusApps, result.app)
+		appStatuses = append(appStatuses, result.status)
+	}
+
+	summary := newEnvironmentsSummary(statusApps, appStatuses)
+	summary.statusErrors = statusErrors
+	return summary, nil
+}
+
+// newEnvironmentsSummary creates a summary from apps and their status. appStatuses must be in the same order as apps.
+func newEnvironmentsSummary(apps []model.App, appStatuses []*model.AppStatus) *environmentsSummary {
+	summary := &environmentsSummary{
+		health:      map[string]model.EnvironmentStatus{},
+		deployments: map[string][]environmentDeployment{},
+	}
+
+	for idx, appStatus := range appStatuses {
+		for _, environmentStatus := range appStatus.Environments {
+			// An unhealthy status from any app takes precedence
+			if existing, ok := summary.health[environmentStatus.EnvironmentName]; !ok || existing.Healthy {
+				summary.health[environmentStatus.EnvironmentName] = environmentStatus
+			}
+		}
+		for _, deployment := range appStatus.Deployments {
+			summary.deployments[deployment.EnvironmentName] = append(summary.deployments[deployment.EnvironmentName], environmentDeployment{app: apps[idx], status: deployment})
+		}
+	}
+
+	return summary
+}
+
+// healthStatus returns the health of an environment and the reason that it is unhealthy or unknown. The health is unknown
+// unless the environment is unhealthy when the status of any app could not be retrieved.
+func (summary *environmentsSummary) healthStatus(environmentName string) (string, string) {
+	environmentStatus, ok := summary.health[environmentName]
+	switch {
+	case ok && !environmentStatus.Healthy:
+		return environmentHealthUnhealthy, environmentStatus.Reason
+	case summary.statusErrors > 0:
+		return environmentHealthUnknown, fmt.Sprintf("unable to get the status of %d app(s)", summary.statusErrors)
+	case !ok:
+		return environmentHealthUnknown, ""
+	default:
+		return environmentHealthHealthy, ""
+	}
+}
+
+type environmentsDescribeView struct {
+	environmentName string
+	config          *model.EnvironmentConfig
+	summary         *environmentsSummary
+}
+
+type environmentDescribeModel struct {
+	Name              string                               `json:"name"`
+	PublicGatewayHost string                               `json:"publicGatewayHost"`
+	Health            string                               `json:"health"`
+	Reason            string                               `json:"reason,omitempty"`
+	Deployments       []environmentDeploymentDescribeModel `json:"deployments"`
+}
+
+type environmentDeploymentDescribeModel struct {
+	App           string                    `json:"app"`
+	Namespace     string                    `json:"namespace"`
+	Name          string                    `json:"name"`
+	RiserRevision int64                     `json:"riserRevision"`
+	DockerImage   string                    `json:"dockerImage"`
+	Status        string                    `json:"status"`
+	Reason        string                    `json:"reason,omitempty"`
+	Traffic       []appTrafficDescribeModel `json:"traffic"`
+}
+
+func (view *environmentsDescribeView) model() *environmentDescribeModel {
+	health, reason := view.summary.healthStatus(view.environmentName)
+	describeModel := &environmentDescribeModel{
+		Name:              view.environmentName,
+		PublicGatewayHost: view.config.PublicGatewayHost,
+		Health:            health,
+		Reason:            reason,
+		Deployments:       []environmentDeploymentDescribeModel{},
+	}
+
+	for _, deployment := range view.summary.deployments[view.environmentName] {
+		deploymentModel := environmentDeploymentDescribeModel{
+			App:           string(deployment.app.Name),
+			Namespace:     string(deployment.app.Namespace),
+			Name:          deployment.status.DeploymentName,
+			RiserRevision: deployment.status.RiserRevision,
+			Traffic:       []appTrafficDescribeModel{},
+		}
+		for _, revision := range status.GetRevisionStatus(&deployment.status, true) {
+			if revision.RiserRevision == deployment.status.RiserRevision {
+				deploymentModel.DockerImage = revision.DockerImage
+				deploymentModel.Status = revision.RevisionStatus
+				deploymentModel.Reason = revision.RevisionStatusReason
+			}
+			if revision.Traffic.Percent != nil && *revision.Traffic.Percent > 0 {
+				deploymentModel.Traffic = append(deploymentModel.Traffic, appTrafficDescribeModel{RiserRevision: revision.RiserRevision, Percent: *revision.Traffic.Percent})
+			}
+		}
+		describeModel.Deployments = append(describeModel.Deployments, deploymentModel)
+	}
+
+	sort.SliceStable(describeModel.Deployments, func(i, j int) bool {
+		a, b := describeModel.Deployments[i], describeModel.Deployments[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.App != b.App {
+			return a.App < b.App
+		}
+		return a.Name < b.Name
+	})
+
+	return describeModel
+}
+
+func (view *environmentsDescribeView) RenderHuman(writer io.Writer) error {
+	describeModel := view.model()
+	outStr := fmt.Sprintf("Name: %s\n", describeModel.Name)
+	outStr += fmt.Sprintf("Public Gateway Host: %s\n", describeModel.PublicGatewayHost)
+	outStr += fmt.Sprintf("Health: %s\n", formatEnvironmentHealth(describeModel.Health))
+	if describeModel.Reason != "" {
+		outStr += fmt.Sprintf("Reason: %s\n", describeModel.Reason)
+	}
+	if describeModel.Health == environmentHealthUnknown {
+		outStr += style.Muted("The health of an environment is only reported once an app has been deployed to it.\n")
+	}
+
+	if len(describeModel.Deployments) == 0 {
+		outStr += fmt.Sprintf("\nThere are no deployments in the environment %q.\n", describeModel.Name)
+	} else {
+		deploymentsTable := table.Default().Header("Namespace", "App", "Deployment", "Rev", "Docker Tag", "Traffic", "Status")
+		for _, deployment := range describeModel.Deployments {
+			deploymentsTable.AddRow(
+				deployment.Namespace,
+				deployment.App,
+				deployment.Name,
+				fmt.Sprintf("%d", deployment.RiserRevision),
+				formatDockerTag(deployment.DockerImage),
+				formatAppTraffic(deployment.Traffic),
+				formatRevisionStatus(deployment.Status),
+			)
+		}
+		outStr += "\nDeployments:\n"
+		outStr += deploymentsTable.String() + "\n"
+	}
+
+	_, err := writer.Write([]byte(outStr))
+	return err
+}
+
+func (view *environmentsDescribeView) RenderJson(writer io.Writer) error {
+	return ui.RenderJson(view.model(), writer)
+}
+
+func formatEnvironmentHealth(health string) string {
+	switch health {
+	case environmentHealthHealthy:
+		return style.Good(health)
+	case environmentHealthUnhealthy:
+		return style.Bad(health)
+	default:
+		return style.Muted(health)
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"riser/pkg/util"
+	"testing"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newEnvironmentsSummary(t *testing.T) {
+	apps := []model.App{{Name: "app1", Namespace: "apps"}, {Name: "app2", Namespace: "apps"}}
+	appStatuses := []*model.AppStatus{
+		{
+			Environments: []model.EnvironmentStatus{{EnvironmentName: "dev", Healthy: true}, {EnvironmentName: "prod", Healthy: true}},
+			Deployments:  []model.DeploymentStatus{{DeploymentName: "app1", EnvironmentName: "dev"}},
+		},
+		{
+			Environments: []model.EnvironmentStatus{{EnvironmentName: "prod", Healthy: false, Reason: "no ping"}, {EnvironmentName: "dev", Healthy: true}},
+			Deployments:  []model.DeploymentStatus{{DeploymentName: "app2", EnvironmentName: "dev"}, {DeploymentName: "app2", EnvironmentName: "prod"}},
+		},
+	}
+
+	summary := newEnvironmentsSummary(apps, appStatuses)
+
+	tests := []struct {
+		environmentName string
+		health          string
+		reason          string
+	}{
+		{"dev", environmentHealthHealthy, ""},
+		// An unhealthy status from any app takes precedence
+		{"prod", environmentHealthUnhealthy, "no ping"},
+		{"test", environmentHealthUnknown, ""},
+	}
+	for _, tt := range tests {
+		health, reason := summary.healthStatus(tt.environmentName)
+		assert.Equal(t, tt.health, health, tt.environmentName)
+		assert.Equal(t, tt.reason, reason, tt.environmentName)
+	}
+
+	require.Len(t, summary.deployments["dev"], 2)
+	assert.EqualValues(t, "app1", summary.deployments["dev"][0].app.Name)
+	assert.EqualValues(t, "app2", summary.deployments["dev"][1].app.Name)
+	require.Len(t, summary.deployments["prod"], 1)
+}
+
+func Test_getEnvironmentsSummary_UnknownWhenStatusFails(t *testing.T) {
+	appsClient := &fakeAppsClient{
+		ListFn: func() ([]model.App, error) {
+			return []model.App{{Name: "app1", Namespace: "apps"}, {Name: "app2", Namespace: "apps"}}, nil
+		},
+		GetStatusFn: func(name, namespace string) (*model.AppStatus, error) {
+			if name == "app2" {
+				return nil, errors.New("broken")
+			}
+			return &model.AppStatus{
+				Environments: []model.EnvironmentStatus{{EnvironmentName: "dev", Healthy: true}, {EnvironmentName: "prod", Healthy: false, Reason: "no ping"}},
+				Deployments:  []model.DeploymentStatus{{DeploymentName: "app1", EnvironmentName: "dev"}},
+			}, nil
+		},
+	}
+
+	summary, err := getEnvironmentsSummary(appsClient)
+
+	require.NoError(t, err)
+	health, reason := summary.healthStatus("dev")
+	assert.Equal(t, environmentHealthUnknown, health)
+	assert.Equal(t, "unable to get the status of 1 app(s)", reason)
+	health, reason = summary.healthStatus("prod")
+	assert.Equal(t, environmentHealthUnhealthy, health)
+	assert.Equal(t, "no ping", reason)
+	require.Len(t, summary.deployments["dev"], 1)
+	assert.EqualValues(t, "app1", summary.deployments["dev"][0].app.Name)
+}
+
+func Test_environmentsDescribeView_RenderJson(t *testing.T) {
+	apps := []model.App{{Name: "web", Namespace: "apps"}, {Name: "api", Namespace: "apps"}}
+	appStatuses := []*model.AppStatus{
+		{
+			Environments: []model.EnvironmentStatus{{EnvironmentName: "dev", Healthy: true}},
+			Deployments: []model.DeploymentStatus{
+				{
+					DeploymentName:  "web",
+					EnvironmentName: "dev",
+					RiserRevision:   1,
+					DeploymentStatusMutable: model.DeploymentStatusMutable{
+						Revisions: []model.DeploymentRevisionStatus{{Name: "web-1", RiserRevision: 1, DockerImage: "web:v1", RevisionStatus: model.RevisionStatusReady}},
+						Traffic:   []model.DeploymentTrafficStatus{{RevisionName: "web-1", Percent: util.PtrInt64(100)}},
+					},
+				},
+			},
+		},
+		{
+			Deployments: []model.DeploymentStatus{{DeploymentName: "api", EnvironmentName: "dev"}, {DeploymentName: "api", EnvironmentName: "prod"}},
+		},
+	}
+	view := &environmentsDescribeView{
+		environmentName: "dev",
+		config:          &model.EnvironmentConfig{PublicGatewayHost: "dev.riser"},
+		summary:         newEnvironmentsSummary(apps, appStatuses),
+	}
+
+	var b bytes.Buffer
+	err := view.RenderJson(&b)
+
+	require.NoError(t, err)
+	result := &environmentDescribeModel{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), result))
+	assert.Equal(t, "dev", result.Name)
+	assert.Equal(t, "dev.riser", result.PublicGatewayHost)
+	assert.Equal(t, environmentHealthHealthy, result.Health)
+	// Sorted by namespace then app
+	require.Len(t, result.Deployments, 2)
+	assert.Equal(t, "api", result.Deployments[0].App)
+	assert.Equal(t, "web", result.Deployments[1].App)
+	assert.Equal(t, "web:v1", result.Deployments[1].DockerImage)
+	assert.Equal(t, model.RevisionStatusReady, result.Deployments[1].Status)
+	assert.Equal(t, []appTrafficDescribeModel{{RiserRevision: 1, Percent: 100}}, result.Deployments[1].Traffic)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/riser-platform/riser-server/api/v1/model"
+)
+
+type fakeAppsClient struct {
+	ListFn      func() ([]model.App, error)
+	GetStatusFn func(name, namespace string) (*model.AppStatus, error)
+}
+
+func (fake *fakeAppsClient) List() ([]model.App, error) {
+	return fake.ListFn()
+}
+
+func (fake *fakeAppsClient) Create(newApp *model.NewApp) (*model.App, error) {
+	panic("NI")
+}
+
+func (fake *fakeAppsClient) Get(name, namespace string) (*model.App, error) {
+	panic("NI")
+}
+
+func (fake *fakeAppsClient) GetStatus(name, namespace string) (*model.AppStatus, error) {
+	return fake.GetStatusFn(name, namespace)
+}
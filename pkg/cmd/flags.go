@@ -1,11 +1,11 @@
 package cmd
 
 import (
+	"fmt"
 	"riser/pkg/config"
+	"riser/pkg/rc"
 	"riser/pkg/ui"
 
-	validation "github.com/go-ozzo/ozzo-validation/v3"
-
 	"github.com/spf13/cobra"
 
 	"github.com/spf13/pflag"
@@ -37,15 +37,87 @@ func addDeploymentNameFlag(flags *pflag.FlagSet, deploymentName *string) {
 	}
 }
 
-// addNamespaceFlag adds the --namespace flag
+// addNamespaceFlag adds the --namespace flag. See applyContextDefaultNamespace for how the context's default namespace is applied.
 func addNamespaceFlag(flags *pflag.FlagSet, namespace *string) {
 	defaultAppNamespace := config.SafeLoadDefaultAppNamespace()
-	flags.StringVarP(namespace, "namespace", "n", defaultAppNamespace, "The namespace for a resource.")
+	flags.StringVarP(namespace, "namespace", "n", defaultAppNamespace, "The namespace for a resource. Defaults to the namespace in the app config, then to the context's default namespace.")
+}
+
+// namespaceFilterAnnotation marks a --namespace flag that filters results. Filters are never defaulted.
+const namespaceFilterAnnotation = "riser_namespace_filter"
+
+// addNamespaceFilterFlag adds the --namespace flag for filtering results by namespace. Unlike addNamespaceFlag, all
+// namespaces are included by default.
+func addNamespaceFilterFlag(flags *pflag.FlagSet, namespace *string) {
+	flags.StringVarP(namespace, "namespace", "n", "", "Only include results in this namespace")
+	_ = flags.SetAnnotation("namespace", namespaceFilterAnnotation, []string{"true"})
+}
+
+// applyContextDefaultNamespace sets the --namespace flag to the context's default namespace when the flag was not specified
+// and the app config does not specify a namespace. This must be called after flags are parsed so that --context is honored.
+func applyContextDefaultNamespace(cmd *cobra.Command, runtimeConfig *rc.RuntimeConfiguration) error {
+	namespaceFlag := cmd.Flags().Lookup("namespace")
+	if namespaceFlag == nil || namespaceFlag.Changed {
+		return nil
+	}
+	if _, isFilter := namespaceFlag.Annotations[namespaceFilterAnnotation]; isFilter {
+		return nil
+	}
+
+	currentContext, err := runtimeConfig.CurrentContext()
+	if err != nil || currentContext.DefaultNamespace == "" {
+		return nil
+	}
+
+	return namespaceFlag.Value.Set(config.SafeLoadDefaultAppNamespaceWithFallback(currentContext.DefaultNamespace))
 }
 
 // addOutputFlag adds the --output flag and sets the output format in the ui package
 func addOutputFlag(flags *pflag.FlagSet) {
-	flags.VarP(&OutputFormat{val: ui.OutputFormatHuman}, "output", "o", "Output format. One of: human|json")
+	flags.VarP(&OutputFormat{val: ui.OutputFormatHuman}, "output", "o", "Output format. One of: human|json|yaml|wide|csv|jsonpath=(template)|go-template=(template)|custom-columns=(HEADER:.path,...)")
+}
+
+// listOptions are the filtering, sorting, and pagination options shared by list commands
+type listOptions struct {
+	selector string
+	sortBy   string
+	limit    int
+	offset   int
+}
+
+// addListFlags adds the --selector, --sort-by, --limit, and --offset flags
+func addListFlags(flags *pflag.FlagSet, options *listOptions) {
+	flags.StringVarP(&options.selector, "selector", "l", "", "Filter by column using a comma separated list of key=value, key!=value, or key~=regex (e.g. \"name~=^api-\")")
+	flags.StringVar(&options.sortBy, "sort-by", "", "Sort by a column. Prefix with \"-\" to sort in descending order (e.g. \"-name\")")
+	flags.IntVar(&options.limit, "limit", 0, "The maximum number of results. 0 for no limit")
+	flags.IntVar(&options.offset, "offset", 0, "The number of results to skip")
+}
+
+// apply filters, sorts, and then paginates the view
+func (options *listOptions) apply(view *ui.BasicTableView) error {
+	if options.limit < 0 || options.offset < 0 {
+		return ui.NewError(ui.ErrorCodeUsage, "--limit and --offset must not be negative")
+	}
+
+	selector, err := ui.ParseSelector(options.selector)
+	if err != nil {
+		return ui.NewError(ui.ErrorCodeUsage, err.Error())
+	}
+
+	err = view.Filter(selector)
+	if err != nil {
+		return ui.NewError(ui.ErrorCodeUsage, fmt.Sprintf("invalid --selector: %v", err))
+	}
+
+	if options.sortBy != "" {
+		err = view.SortBy(options.sortBy)
+		if err != nil {
+			return ui.NewError(ui.ErrorCodeUsage, fmt.Sprintf("invalid --sort-by: %v", err))
+		}
+	}
+
+	view.Paginate(options.offset, options.limit)
+	return nil
 }
 
 type OutputFormat struct {
@@ -57,7 +129,7 @@ func (outputFormat *OutputFormat) String() string {
 }
 
 func (outputFormat *OutputFormat) Set(inVal string) error {
-	err := validation.Validate(inVal, validation.In(ui.OutputFormatHuman, ui.OutputFormatJson).Error("Must be one of: human|json"))
+	err := ui.ValidateOutputFormat(inVal)
 	if err != nil {
 		return err
 	}
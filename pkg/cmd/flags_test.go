@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"riser/pkg/rc"
+	"riser/pkg/ui"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applyContextDefaultNamespace(t *testing.T) {
+	tests := []struct {
+		args             []string
+		defaultNamespace string
+		expected         string
+	}{
+		{[]string{}, "myns", "myns"},
+		{[]string{"--namespace", "flagns"}, "myns", "flagns"},
+		{[]string{}, "", "apps"},
+	}
+
+	for _, tt := range tests {
+		runtimeConfig := &rc.RuntimeConfiguration{}
+		runtimeConfig.SetContext(&rc.Context{Name: "a", DefaultNamespace: tt.defaultNamespace})
+		var namespace string
+		cmd := &cobra.Command{}
+		addNamespaceFlag(cmd.Flags(), &namespace)
+		assert.NoError(t, cmd.ParseFlags(tt.args))
+
+		err := applyContextDefaultNamespace(cmd, runtimeConfig)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, namespace)
+	}
+}
+
+func Test_applyContextDefaultNamespace_Filter(t *testing.T) {
+	runtimeConfig := &rc.RuntimeConfiguration{}
+	runtimeConfig.SetContext(&rc.Context{Name: "a", DefaultNamespace: "myns"})
+	var namespace string
+	cmd := &cobra.Command{}
+	addNamespaceFilterFlag(cmd.Flags(), &namespace)
+	assert.NoError(t, cmd.ParseFlags([]string{}))
+
+	err := applyContextDefaultNamespace(cmd, runtimeConfig)
+
+	assert.NoError(t, err)
+	assert.Empty(t, namespace)
+}
+
+func Test_listOptions_apply(t *testing.T) {
+	tests := []struct {
+		options  listOptions
+		expected []string
+		err      string
+	}{
+		{listOptions{}, []string{"b", "c", "a"}, ""},
+		{listOptions{sortBy: "name"}, []string{"a", "b", "c"}, ""},
+		{listOptions{sortBy: "-name", limit: 2}, []string{"c", "b"}, ""},
+		{listOptions{sortBy: "name", offset: 1, limit: 1}, []string{"b"}, ""},
+		{listOptions{selector: "name!=b", sortBy: "name"}, []string{"a", "c"}, ""},
+		{listOptions{selector: "name~=["}, nil, "invalid selector \"name~=[\": error parsing regexp: missing closing ]: `[`"},
+		{listOptions{selector: "foo=bar"}, nil, "invalid --selector: unknown column \"foo\". Must be one of: name"},
+		{listOptions{sortBy: "foo"}, nil, "invalid --sort-by: unknown column \"foo\". Must be one of: name"},
+		{listOptions{limit: -1}, nil, "--limit and --offset must not be negative"},
+	}
+
+	for _, tt := range tests {
+		view := &ui.BasicTableView{}
+		view.Header("Name")
+		view.AddRow("b")
+		view.AddRow("c")
+		view.AddRow("a")
+
+		err := tt.options.apply(view)
+
+		if tt.err != "" {
+			assert.Equal(t, tt.err, err.Error())
+			assert.Equal(t, ui.ErrorCodeUsage, ui.ClassifyError(err).Code)
+		} else {
+			assert.NoError(t, err)
+			var b bytes.Buffer
+			assert.NoError(t, view.RenderJson(&b))
+			result := []map[string]string{}
+			assert.NoError(t, json.Unmarshal(b.Bytes(), &result))
+			names := []string{}
+			for _, row := range result {
+				names = append(names, row["Name"])
+			}
+			assert.Equal(t, tt.expected, names)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"riser/pkg/rc"
+	"riser/pkg/ui"
+	"sync"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/riser-platform/riser-server/pkg/sdk"
+	"github.com/spf13/cobra"
+)
+
+const defaultStatusConcurrency = 8
+
+func newFleetCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fleet",
+		Short: "Commands for viewing every app across all namespaces and environments",
+	}
+
+	cmd.AddCommand(newFleetStatusCommand(runtimeConfig))
+
+	return cmd
+}
+
+func newFleetStatusCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
+	var namespace string
+	concurrency := defaultStatusConcurrency
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Gets the status of every app in every namespace and environment",
+		Long: "Gets the status of every app in every namespace and environment. Unhealthy revisions, deployments whose latest revision is not serving traffic, " +
+			"and unhealthy environments are reported as problems. Exits with a non-zero exit code when any problems are found.",
+		Example: "  riser fleet status -o json",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if concurrency < 1 {
+				ui.ExitError(ui.NewError(ui.ErrorCodeUsage, "--concurrency must be greater than zero"))
+			}
+
+			currentContext := safeCurrentContext(runtimeConfig)
+			riserClient := getRiserClient(currentContext)
+			apps, err := riserClient.Apps.List()
+			ui.ExitIfErrorMsg(err, "Error listing apps")
+
+			if namespace != "" {
+				namespaceApps := []model.App{}
+				for _, app := range apps {
+					if string(app.Namespace) == namespace {
+						namespaceApps = append(namespaceApps, app)
+					}
+				}
+				apps = namespaceApps
+			}
+
+			view := newFleetStatusView(getAppStatuses(riserClient.Apps, apps, concurrency))
+			ui.RenderView(view)
+
+			if len(view.model.Problems) > 0 {
+				ui.ExitError(ui.NewError(ui.ErrorCodeUnhealthy, fmt.Sprintf("Found %d problem(s)", len(view.model.Problems))))
+			}
+		},
+	}
+
+	addNamespaceFilterFlag(cmd.Flags(), &namespace)
+	addOutputFlag(cmd.Flags())
+	cmd.Flags().IntVar(&concurrency, "concurrency", defaultStatusConcurrency, "The maximum number of app statuses to request at the same time")
+
+	return cmd
+}
+
+type appStatusResult struct {
+	app    model.App
+	status *model.AppStatus
+	err    error
+}
+
+// getAppStatuses gets the status of each app with at most concurrency requests in flight. Results are in the same order as apps.
+func getAppStatuses(appsClient sdk.AppsClient, apps []model.App, concurrency int) []appStatusResult {
+	results := make([]appStatusResult, len(apps))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for idx, app := range apps {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(idx int, app model.App) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			appStatus, err := appsClient.GetStatus(string(app.Name), string(app.Namespace))
+			results[idx] = appStatusResult{app: app, status: appStatus, err: err}
+		}(idx, app)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"riser/pkg/status"
+	"riser/pkg/ui"
+	"riser/pkg/ui/style"
+	"riser/pkg/ui/table"
+	"sort"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+)
+
+type fleetStatusView struct {
+	model *fleetStatusModel
+}
+
+type fleetStatusModel struct {
+	Healthy      bool                          `json:"healthy"`
+	Apps         int                           `json:"apps"`
+	Deployments  []fleetDeploymentStatusModel  `json:"deployments"`
+	Environments []fleetEnvironmentStatusModel `json:"environments"`
+	Problems     []fleetProblemModel           `json:"problems"`
+}
+
+type fleetDeploymentStatusModel struct {
+	Namespace     string                    `json:"namespace"`
+	App           string                    `json:"app"`
+	Name          string                    `json:"name"`
+	Environment   string                    `json:"environment"`
+	RiserRevision int64                     `json:"riserRevision"`
+	DockerImage   string                    `json:"dockerImage"`
+	Status        string                    `json:"status"`
+	Reason        string                    `json:"reason,omitempty"`
+	Traffic       []appTrafficDescribeModel `json:"traffic"`
+	Healthy       bool                      `json:"healthy"`
+}
+
+type fleetEnvironmentStatusModel struct {
+	Name   string `json:"name"`
+	Health string `json:"health"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// fleetProblemModel describes something that is unhealthy. Fields that do not apply to the problem are omitted.
+type fleetProblemModel struct {
+	Namespace   string `json:"namespace,omitempty"`
+	App         string `json:"app,omitempty"`
+	Deployment  string `json:"deployment,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	Message     string `json:"message"`
+}
+
+func newFleetStatusView(results []appStatusResult) *fleetStatusView {
+	fleet := &fleetStatusModel{
+		Apps:         len(results),
+		Deployments:  []fleetDeploymentStatusModel{},
+		Environments: []fleetEnvironmentStatusModel{},
+		Problems:     []fleetProblemModel{},
+	}
+
+	apps := []model.App{}
+	appStatuses := []*model.AppStatus{}
+	for _, result := range results {
+		if result.err != nil {
+			fleet.Problems = append(fleet.Problems, fleetProblemModel{
+				Namespace: string(result.app.Namespace),
+				App:       string(result.app.Name),
+				Message:   fmt.Sprintf("Error getting status: %v", result.err),
+			})
+			continue
+		}
+		apps = append(apps, result.app)
+		appStatuses = append(appStatuses, result.status)
+		for idx := range result.status.Deployments {
+			deployment, problems := newFleetDeploymentStatus(result.app, &result.status.Deployments[idx])
+			fleet.Deployments = append(fleet.Deployments, deployment)
+			fleet.Problems = append(fleet.Problems, problems...)
+		}
+	}
+
+	summary := newEnvironmentsSummary(apps, appStatuses)
+	for environmentName := range summary.health {
+		health, reason := summary.healthStatus(environmentName)
+		fleet.Environments = append(fleet.Environments, fleetEnvironmentStatusModel{Name: environmentName, Health: health, Reason: reason})
+		if health == environmentHealthUnhealthy {
+			fleet.Problems = append(fleet.Problems, fleetProblemModel{
+				Environment: environmentName,
+				Message:     fmt.Sprintf("Environment is not healthy: %s", reason),
+			})
+		}
+	}
+
+	sort.Slice(fleet.Environments, func(i, j int) bool {
+		return fleet.Environments[i].Name < fleet.Environments[j].Name
+	})
+	sort.SliceStable(fleet.Deployments, func(i, j int) bool {
+		a, b := fleet.Deployments[i], fleet.Deployments[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.App != b.App {
+			return a.App < b.App
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Environment < b.Environment
+	})
+	sort.SliceStable(fleet.Problems, func(i, j int) bool {
+		a, b := fleet.Problems[i], fleet.Problems[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.App != b.App {
+			return a.App < b.App
+		}
+		if a.Deployment != b.Deployment {
+			return a.Deployment < b.Deployment
+		}
+		return a.Environment < b.Environment
+	})
+
+	fleet.Healthy = len(fleet.Problems) == 0
+	return &fleetStatusView{model: fleet}
+}
+
+// newFleetDeploymentStatus returns the status of the latest revision of a deployment and any problems with the deployment
+func newFleetDeploymentStatus(app model.App, deploymentStatus *model.DeploymentStatus) (fleetDeploymentStatusModel, []fleetProblemModel) {
+	deployment := fleetDeploymentStatusModel{
+		Namespace:     string(app.Namespace),
+		App:           string(app.Name),
+		Name:          deploymentStatus.DeploymentName,
+		Environment:   deploymentStatus.EnvironmentName,
+		RiserRevision: deploymentStatus.RiserRevision,
+		Status:        model.RevisionStatusUnknown,
+		Traffic:       []appTrafficDescribeModel{},
+	}
+	problems := []fleetProblemModel{}
+	addProblem := func(message string) {
+		problems = append(problems, fleetProblemModel{
+			Namespace:   deployment.Namespace,
+			App:         deployment.App,
+			Deployment:  deployment.Name,
+			Environment: deployment.Environment,
+			Message:     message,
+		})
+	}
+
+	latestServing := false
+	for _, revision := range status.GetRevisionStatus(deploymentStatus, true) {
+		serving := revision.Traffic.Percent != nil && *revision.Traffic.Percent > 0
+		if serving {
+			deployment.Traffic = append(deployment.Traffic, appTrafficDescribeModel{RiserRevision: revision.RiserRevision, Percent: *revision.Traffic.Percent})
+		}
+		if revision.RiserRevision == deploymentStatus.RiserRevision {
+			deployment.DockerImage = revision.DockerImage
+			deployment.Status = revision.RevisionStatus
+			deployment.Reason = revision.RevisionStatusReason
+			latestServing = latestServing || serving
+		}
+		if revision.RevisionStatus == model.RevisionStatusUnhealthy {
+			addProblem(fmt.Sprintf("Revision %d is unhealthy: %s", revision.RiserRevision, revision.RevisionStatusReason))
+		}
+	}
+
+	// An unhealthy latest revision is already reported
+	if !latestServing && deployment.Status != model.RevisionStatusUnhealthy {
+		addProblem(fmt.Sprintf("The latest revision (%d) is not serving traffic", deploymentStatus.RiserRevision))
+	}
+
+	deployment.Healthy = len(problems) == 0
+	return deployment, problems
+}
+
+func (view *fleetStatusView) RenderHuman(writer io.Writer) error {
+	outStr := ""
+	if len(view.model.Deployments) == 0 {
+		outStr += "There are no deployments.\n"
+	} else {
+		deploymentsTable := table.Default().Header("Namespace", "App", "Deployment", "Env", "Rev", "Docker Tag", "Traffic", "Status")
+		for _, deployment := range view.model.Deployments {
+			deploymentsTable.AddRow(
+				deployment.Namespace,
+				deployment.App,
+				deployment.Name,
+				deployment.Environment,
+				fmt.Sprintf("%d", deployment.RiserRevision),
+				formatDockerTag(deployment.DockerImage),
+				formatAppTraffic(deployment.Traffic),
+				formatRevisionStatus(deployment.Status),
+			)
+		}
+		outStr += deploymentsTable.String() + "\n"
+	}
+
+	outStr += fmt.Sprintf("\n%d app(s), %d deployment(s), %d environment(s)\n", view.model.Apps, len(view.model.Deployments), len(view.model.Environments))
+	if view.model.Healthy {
+		outStr += style.Good("No problems found") + "\n"
+	} else {
+		outStr += style.Bad(fmt.Sprintf("Problems (%d):", len(view.model.Problems))) + "\n"
+		for _, problem := range view.model.Problems {
+			outStr += fmt.Sprintf("  %s: %s\n", formatFleetProblemSubject(problem), problem.Message)
+		}
+	}
+
+	_, err := writer.Write([]byte(outStr))
+	return err
+}
+
+func (view *fleetStatusView) RenderJson(writer io.Writer) error {
+	return ui.RenderJson(view.model, writer)
+}
+
+func formatFleetProblemSubject(problem fleetProblemModel) string {
+	switch {
+	case problem.Deployment != "":
+		return fmt.Sprintf("%s/%s (%s)", problem.Namespace, problem.Deployment, problem.Environment)
+	case problem.App != "":
+		return fmt.Sprintf("%s/%s", problem.Namespace, problem.App)
+	default:
+		return fmt.Sprintf("environment %q", problem.Environment)
+	}
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"riser/pkg/util"
+	"testing"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newFleetDeploymentStatus(t *testing.T) {
+	app := model.App{Name: "myapp", Namespace: "apps"}
+	tests := []struct {
+		name     string
+		status   model.DeploymentStatus
+		expected string
+		problems []string
+	}{
+		{
+			name: "serving",
+			status: model.DeploymentStatus{
+				RiserRevision: 2,
+				DeploymentStatusMutable: model.DeploymentStatusMutable{
+					ObservedRiserRevision:     2,
+					LatestCreatedRevisionName: "rev2",
+					Revisions:                 []model.DeploymentRevisionStatus{{Name: "rev2", RiserRevision: 2, RevisionStatus: model.RevisionStatusReady}},
+					Traffic:                   []model.DeploymentTrafficStatus{{RevisionName: "rev2", Percent: util.PtrInt64(100)}},
+				},
+			},
+			expected: model.RevisionStatusReady,
+			problems: []string{},
+		},
+		{
+			name: "latest not serving",
+			status: model.DeploymentStatus{
+				RiserRevision: 2,
+				DeploymentStatusMutable: model.DeploymentStatusMutable{
+					ObservedRiserRevision:     2,
+					LatestCreatedRevisionName: "rev2",
+					Revisions: []model.DeploymentRevisionStatus{
+						{Name: "rev1", RiserRevision: 1, RevisionStatus: model.RevisionStatusReady},
+						{Name: "rev2", RiserRevision: 2, RevisionStatus: model.RevisionStatusReady},
+					},
+					Traffic: []model.DeploymentTrafficStatus{{RevisionName: "rev1", Percent: util.PtrInt64(100)}},
+				},
+			},
+			expected: model.RevisionStatusReady,
+			problems: []string{"The latest revision (2) is not serving traffic"},
+		},
+		{
+			name: "not observed",
+			status: model.DeploymentStatus{
+				RiserRevision:           3,
+				DeploymentStatusMutable: model.DeploymentStatusMutable{ObservedRiserRevision: 2},
+			},
+			expected: model.RevisionStatusWaiting,
+			problems: []string{"The latest revision (3) is not serving traffic"},
+		},
+		{
+			name: "unhealthy",
+			status: model.DeploymentStatus{
+				RiserRevision: 2,
+				DeploymentStatusMutable: model.DeploymentStatusMutable{
+					ObservedRiserRevision:     2,
+					LatestCreatedRevisionName: "rev2",
+					Revisions: []model.DeploymentRevisionStatus{
+						{Name: "rev1", RiserRevision: 1, RevisionStatus: model.RevisionStatusReady},
+						{Name: "rev2", RiserRevision: 2, RevisionStatus: model.RevisionStatusUnhealthy, RevisionStatusReason: "CrashLoopBackOff"},
+					},
+					Traffic: []model.DeploymentTrafficStatus{{RevisionName: "rev1", Percent: util.PtrInt64(100)}},
+				},
+			},
+			expected: model.RevisionStatusUnhealthy,
+			problems: []string{"Revision 2 is unhealthy: CrashLoopBackOff"},
+		},
+	}
+
+	for _, tt := range tests {
+		deployment, problems := newFleetDeploymentStatus(app, &tt.status)
+
+		assert.Equal(t, tt.expected, deployment.Status, tt.name)
+		assert.Equal(t, len(tt.problems) == 0, deployment.Healthy, tt.name)
+		messages := []string{}
+		for _, problem := range problems {
+			messages = append(messages, problem.Message)
+		}
+		assert.Equal(t, tt.problems, messages, tt.name)
+	}
+}
+
+func Test_fleetStatusView_RenderJson(t *testing.T) {
+	servingStatus := model.DeploymentStatusMutable{
+		ObservedRiserRevision:     1,
+		LatestCreatedRevisionName: "rev1",
+		Revisions:                 []model.DeploymentRevisionStatus{{Name: "rev1", RiserRevision: 1, DockerImage: "web:v1", RevisionStatus: model.RevisionStatusReady}},
+		Traffic:                   []model.DeploymentTrafficStatus{{RevisionName: "rev1", Percent: util.PtrInt64(100)}},
+	}
+	results := []appStatusResult{
+		{
+			app: model.App{Name: "web", Namespace: "apps"},
+			status: &model.AppStatus{
+				Environments: []model.EnvironmentStatus{{EnvironmentName: "prod", Healthy: false, Reason: "no ping"}, {EnvironmentName: "dev", Healthy: true}},
+				Deployments: []model.DeploymentStatus{
+					{DeploymentName: "web", EnvironmentName: "prod", RiserRevision: 1, DeploymentStatusMutable: servingStatus},
+					{DeploymentName: "web", EnvironmentName: "dev", RiserRevision: 1, DeploymentStatusMutable: servingStatus},
+				},
+			},
+		},
+		{app: model.App{Name: "api", Namespace: "apps"}, err: errors.New("broke")},
+	}
+
+	view := newFleetStatusView(results)
+
+	var b bytes.Buffer
+	require.NoError(t, view.RenderJson(&b))
+	result := &fleetStatusModel{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), result))
+	assert.False(t, result.Healthy)
+	assert.Equal(t, 2, result.Apps)
+	require.Len(t, result.Deployments, 2)
+	assert.Equal(t, "dev", result.Deployments[0].Environment)
+	assert.Equal(t, "web:v1", result.Deployments[0].DockerImage)
+	assert.True(t, result.Deployments[0].Healthy)
+	assert.Equal(t, []fleetEnvironmentStatusModel{
+		{Name: "dev", Health: environmentHealthHealthy},
+		{Name: "prod", Health: environmentHealthUnhealthy, Reason: "no ping"},
+	}, result.Environments)
+	assert.Equal(t, []fleetProblemModel{
+		{Environment: "prod", Message: "Environment is not healthy: no ping"},
+		{Namespace: "apps", App: "api", Message: "Error getting status: broke"},
+	}, result.Problems)
+}
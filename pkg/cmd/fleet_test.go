@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getAppStatuses(t *testing.T) {
+	apps := []model.App{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		apps = append(apps, model.App{Name: model.AppName(name), Namespace: "apps"})
+	}
+	var mutex sync.Mutex
+	inFlight := 0
+	maxInFlight := 0
+	appsClient := &fakeAppsClient{
+		GetStatusFn: func(name, namespace string) (*model.AppStatus, error) {
+			mutex.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mutex.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mutex.Lock()
+			inFlight--
+			mutex.Unlock()
+
+			if name == "c" {
+				return nil, errors.New("broke")
+			}
+			return &model.AppStatus{Deployments: []model.DeploymentStatus{{DeploymentName: name}}}, nil
+		},
+	}
+
+	results := getAppStatuses(appsClient, apps, 2)
+
+	assert.Equal(t, 2, maxInFlight)
+	require.Len(t, results, 6)
+	for idx, result := range results {
+		assert.Equal(t, apps[idx], result.app)
+		if result.app.Name == "c" {
+			assert.Equal(t, "broke", result.err.Error())
+			assert.Nil(t, result.status)
+		} else {
+			assert.NoError(t, result.err)
+			assert.Equal(t, string(result.app.Name), result.status.Deployments[0].DeploymentName)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"riser/pkg/logger"
+	"riser/pkg/policy"
+	"riser/pkg/rc"
+	"riser/pkg/ui"
+	uiterminal "riser/pkg/ui/terminal"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/pflag"
+)
+
+// freezeOverrideAuditPath is the file that freeze overrides are appended to
+const freezeOverrideAuditPath = "~/.riser-audit.log"
+
+// environmentGuardOptions are the options for changing an environment that is protected or frozen
+type environmentGuardOptions struct {
+	confirmEnvironment string
+	overrideFreeze     bool
+	reason             string
+}
+
+// addConfirmEnvironmentFlag adds the --confirm-environment flag
+func addConfirmEnvironmentFlag(flags *pflag.FlagSet, confirmEnvironment *string) {
+	flags.StringVar(confirmEnvironment, "confirm-environment", "", "Confirms changes to a protected environment without prompting (e.g. in CI). Must match the name of the environment")
+}
+
+// addEnvironmentGuardFlags adds the --confirm-environment, --override-freeze, and --reason flags
+func addEnvironmentGuardFlags(flags *pflag.FlagSet, options *environmentGuardOptions) {
+	addConfirmEnvironmentFlag(flags, &options.confirmEnvironment)
+	flags.BoolVar(&options.overrideFreeze, "override-freeze", false, "Allows changes to an environment during a freeze window. Requires --reason")
+	flags.StringVar(&options.reason, "reason", "", fmt.Sprintf("The reason for overriding a freeze window. The reason is logged and appended to %s", freezeOverrideAuditPath))
+}
+
+// guardEnvironment returns an error if the environment is frozen and the freeze is not overridden, or if the environment
+// is protected and the user does not confirm the change. Freeze windows and protected environments are configured in the
+// context or in the policy file in policyDir. Returns true if the user confirmed a protected environment so that commands
+// can skip their own confirmation.
+func guardEnvironment(currentContext *rc.Context, policyDir string, environmentName string, options *environmentGuardOptions, auditFields logger.Fields) (bool, error) {
+	warnInvalidFreezeWindows(currentContext)
+	repoPolicy, err := loadEnvironmentPolicy(policyDir)
+	if err != nil {
+		return false, err
+	}
+
+	err = checkFreeze(currentContext, repoPolicy, environmentName, options, auditFields, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	return confirmProtectedEnvironment(currentContext, repoPolicy, environmentName, options.confirmEnvironment)
+}
+
+// checkEnvironmentUnguarded returns an error if the environment is frozen or protected. Use this instead of guardEnvironment
+// where a freeze cannot be overridden and the user cannot be prompted (e.g. in the dashboard). alternative describes how
+// to make the change instead (e.g. "riser rollout").
+func checkEnvironmentUnguarded(currentContext *rc.Context, repoPolicy *policy.Policy, environmentName, alternative string, now time.Time) error {
+	windows := append(append([]policy.FreezeWindow{}, currentContext.FreezeWindows...), repoPolicy.FreezeWindows...)
+	if window := policy.ActiveFreezeWindow(windows, environmentName, now); window != nil {
+		return ui.NewError(ui.ErrorCodeConflict, fmt.Sprintf("%s Use %q to make changes during the freeze.", frozenMessage(environmentName, window), alternative))
+	}
+	if currentContext.IsProtectedEnvironment(environmentName) || repoPolicy.IsProtectedEnvironment(environmentName) {
+		return ui.NewError(ui.ErrorCodeConflict, fmt.Sprintf("The environment %q is protected. Use %q to make changes.", environmentName, alternative))
+	}
+	return nil
+}
+
+// warnInvalidFreezeWindows warns about invalid freeze windows in the context. Freeze windows are edited by hand in the rc
+// file so mistakes must not go unnoticed. Invalid freeze windows are treated as active (see policy.FreezeWindow.IsActive).
+func warnInvalidFreezeWindows(currentContext *rc.Context) {
+	for idx := range currentContext.FreezeWindows {
+		if err := currentContext.FreezeWindows[idx].Validate(); err != nil {
+			logger.Log().Warn(fmt.Sprintf("The context %q has an invalid %v. It is treated as active for the environments that it applies to.", currentContext.Name, err))
+		}
+	}
+}
+
+func loadEnvironmentPolicy(policyDir string) (*policy.Policy, error) {
+	repoPolicy, _, err := policy.Load(policyDir)
+	if err != nil {
+		return nil, &ui.Error{Code: ui.ErrorCodeValidation, Message: err.Error(), Err: err}
+	}
+	if repoPolicy == nil {
+		return &policy.Policy{}, nil
+	}
+	return repoPolicy, nil
+}
+
+// checkFreeze returns an error if the environment is in an active freeze window unless the freeze is overridden with a reason.
+// Overrides are logged and recorded in the audit file.
+func checkFreeze(currentContext *rc.Context, repoPolicy *policy.Policy, environmentName string, options *environmentGuardOptions, auditFields logger.Fields, now time.Time) error {
+	if options.reason != "" && !options.overrideFreeze {
+		return ui.NewError(ui.ErrorCodeUsage, "--reason may only be specified with --override-freeze")
+	}
+	if options.overrideFreeze && options.reason == "" {
+		return ui.NewError(ui.ErrorCodeUsage, "--reason is required with --override-freeze")
+	}
+
+	windows := append(append([]policy.FreezeWindow{}, currentContext.FreezeWindows...), repoPolicy.FreezeWindows...)
+	window := policy.ActiveFreezeWindow(windows, environmentName, now)
+	if window == nil {
+		return nil
+	}
+
+	if !options.overrideFreeze {
+		return ui.NewError(ui.ErrorCodeConflict, fmt.Sprintf("%s Use --override-freeze --reason \"...\" to make changes during the freeze.", frozenMessage(environmentName, window)))
+	}
+
+	return recordFreezeOverride(currentContext, window, environmentName, options.reason, auditFields, now)
+}
+
+func frozenMessage(environmentName string, window *policy.FreezeWindow) string {
+	if err := window.Validate(); err != nil {
+		return fmt.Sprintf("The environment %q is frozen by an invalid freeze window (%v).", environmentName, err)
+	}
+	return fmt.Sprintf("The environment %q is frozen until %s (%s).", environmentName, window.EndTime().Local().Format(time.RFC1123), window.Name)
+}
+
+type freezeOverrideRecord struct {
+	Time         time.Time     `json:"time"`
+	User         string        `json:"user"`
+	Context      string        `json:"context"`
+	Environment  string        `json:"environment"`
+	FreezeWindow string        `json:"freezeWindow"`
+	Reason       string        `json:"reason"`
+	Details      logger.Fields `json:"details,omitempty"`
+}
+
+func recordFreezeOverride(currentContext *rc.Context, window *policy.FreezeWindow, environmentName, reason string, auditFields logger.Fields, now time.Time) error {
+	record := &freezeOverrideRecord{
+		Time:         now.UTC(),
+		User:         os.Getenv("USER"),
+		Context:      currentContext.Name,
+		Environment:  environmentName,
+		FreezeWindow: window.Name,
+		Reason:       reason,
+		Details:      auditFields,
+	}
+
+	fields := logger.Fields{"environment": environmentName, "freezeWindow": window.Name, "reason": reason}
+	for key, value := range auditFields {
+		fields[key] = value
+	}
+	logger.WithFields(fields).Warn(fmt.Sprintf("Overriding the freeze window %q for the environment %q: %s", window.Name, environmentName, reason))
+
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	auditFile, err := os.OpenFile(expandTildeInPath(freezeOverrideAuditPath), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("Error recording the freeze override: %w", err)
+	}
+	defer auditFile.Close()
+	_, err = auditFile.Write(append(recordBytes, '\n'))
+	return err
+}
+
+// confirmProtectedEnvironment requires the user to type the name of a protected environment, or to specify it with
+// --confirm-environment. Returns true if the environment is protected and the change was confirmed. Returns an error if the
+// user does not confirm, if confirmEnvironment does not match, or if confirmation is required but stdin is not a terminal.
+func confirmProtectedEnvironment(currentContext *rc.Context, repoPolicy *policy.Policy, environmentName, confirmEnvironment string) (bool, error) {
+	if confirmEnvironment != "" && confirmEnvironment != environmentName {
+		return false, ui.NewError(ui.ErrorCodeUsage, fmt.Sprintf("--confirm-environment %q does not match the environment %q", confirmEnvironment, environmentName))
+	}
+	if !currentContext.IsProtectedEnvironment(environmentName) && !repoPolicy.IsProtectedEnvironment(environmentName) {
+		return false, nil
+	}
+	if confirmEnvironment != "" {
+		logger.WithFields(logger.Fields{"environment": environmentName}).Warn(fmt.Sprintf("Changing the protected environment %q confirmed with --confirm-environment", environmentName))
+		return true, nil
+	}
+	if !uiterminal.IsStdinTerminal() {
+		return false, ui.NewError(ui.ErrorCodeUsage, fmt.Sprintf("The environment %q is protected. Use --confirm-environment %s to confirm when not running interactively.", environmentName, environmentName))
+	}
+
+	typedName := ""
+	err := survey.AskOne(&survey.Input{
+		Message: fmt.Sprintf("The environment %q is protected. Type the name of the environment to confirm:", environmentName),
+	}, &typedName)
+	if err != nil {
+		return false, err
+	}
+	if typedName != environmentName {
+		return false, ui.NewError(ui.ErrorCodeGeneral, "The environment name did not match. No changes were made.")
+	}
+	return true, nil
+}
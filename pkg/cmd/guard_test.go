@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"riser/pkg/logger"
+	"riser/pkg/policy"
+	"riser/pkg/rc"
+	"riser/pkg/ui"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkFreeze(t *testing.T) {
+	tmpHome, err := ioutil.TempDir("", "riser-home")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpHome)
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", tmpHome)
+
+	currentContext := &rc.Context{
+		Name:          "myctx",
+		FreezeWindows: []policy.FreezeWindow{{Name: "holidays", Environments: []string{"prod"}, Start: "2020-12-24T00:00:00Z", End: "2021-01-04T00:00:00Z"}},
+	}
+	repoPolicy := &policy.Policy{
+		FreezeWindows: []policy.FreezeWindow{{Name: "launch", Environments: []string{"staging"}, Start: "2020-12-24T00:00:00Z", End: "2021-01-04T00:00:00Z"}},
+	}
+	now := time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC)
+	auditFields := logger.Fields{"deployment": "myapp"}
+
+	tests := []struct {
+		environmentName string
+		options         environmentGuardOptions
+		code            ui.ErrorCode
+		err             string
+	}{
+		{"dev", environmentGuardOptions{}, "", ""},
+		{"prod", environmentGuardOptions{}, ui.ErrorCodeConflict, ""},
+		{"staging", environmentGuardOptions{}, ui.ErrorCodeConflict, ""},
+		{"prod", environmentGuardOptions{overrideFreeze: true}, ui.ErrorCodeUsage, "--reason is required with --override-freeze"},
+		{"prod", environmentGuardOptions{reason: "hotfix"}, ui.ErrorCodeUsage, "--reason may only be specified with --override-freeze"},
+		{"prod", environmentGuardOptions{overrideFreeze: true, reason: "hotfix"}, "", ""},
+	}
+
+	for _, tt := range tests {
+		err := checkFreeze(currentContext, repoPolicy, tt.environmentName, &tt.options, auditFields, now)
+
+		if tt.code == "" {
+			assert.NoError(t, err, tt.environmentName)
+		} else {
+			require.Error(t, err, tt.environmentName)
+			assert.Equal(t, tt.code, ui.ClassifyError(err).Code, tt.environmentName)
+			if tt.err != "" {
+				assert.Equal(t, tt.err, err.Error())
+			}
+		}
+	}
+
+	auditBytes, err := ioutil.ReadFile(filepath.Join(tmpHome, ".riser-audit.log"))
+	require.NoError(t, err)
+	record := &freezeOverrideRecord{}
+	require.NoError(t, json.Unmarshal(auditBytes, record))
+	assert.Equal(t, now, record.Time)
+	assert.Equal(t, "myctx", record.Context)
+	assert.Equal(t, "prod", record.Environment)
+	assert.Equal(t, "holidays", record.FreezeWindow)
+	assert.Equal(t, "hotfix", record.Reason)
+	assert.Equal(t, "myapp", record.Details["deployment"])
+}
+
+func Test_confirmProtectedEnvironment(t *testing.T) {
+	currentContext := &rc.Context{ProtectedEnvironments: []string{"prod"}}
+	repoPolicy := &policy.Policy{ProtectedEnvironments: []string{"staging"}}
+
+	confirmed, err := confirmProtectedEnvironment(currentContext, repoPolicy, "dev", "")
+	assert.NoError(t, err)
+	assert.False(t, confirmed)
+
+	confirmed, err = confirmProtectedEnvironment(currentContext, repoPolicy, "staging", "staging")
+	assert.NoError(t, err)
+	assert.True(t, confirmed)
+
+	_, err = confirmProtectedEnvironment(currentContext, repoPolicy, "staging", "prod")
+	assert.Equal(t, `--confirm-environment "prod" does not match the environment "staging"`, err.Error())
+
+	_, err = confirmProtectedEnvironment(currentContext, repoPolicy, "dev", "prod")
+	assert.Equal(t, ui.ErrorCodeUsage, ui.ClassifyError(err).Code)
+
+	// Tests do not run with stdin as a terminal
+	_, err = confirmProtectedEnvironment(currentContext, repoPolicy, "prod", "")
+	assert.Equal(t, `The environment "prod" is protected. Use --confirm-environment prod to confirm when not running interactively.`, err.Error())
+}
+
+func Test_checkEnvironmentUnguarded(t *testing.T) {
+	currentContext := &rc.Context{
+		ProtectedEnvironments: []string{"prod"},
+		FreezeWindows:         []policy.FreezeWindow{{Name: "holidays", Environments: []string{"staging"}, Start: "2020-12-24T00:00:00Z", End: "2021-01-04T00:00:00Z"}},
+	}
+	repoPolicy := &policy.Policy{ProtectedEnvironments: []string{"qa"}}
+	now := time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, checkEnvironmentUnguarded(currentContext, repoPolicy, "dev", "riser rollout", now))
+	for _, environmentName := range []string{"prod", "qa", "staging"} {
+		err := checkEnvironmentUnguarded(currentContext, repoPolicy, environmentName, "riser rollout", now)
+		require.Error(t, err, environmentName)
+		assert.Equal(t, ui.ErrorCodeConflict, ui.ClassifyError(err).Code, environmentName)
+	}
+	assert.Equal(t, `The environment "prod" is protected. Use "riser rollout" to make changes.`,
+		checkEnvironmentUnguarded(currentContext, repoPolicy, "prod", "riser rollout", now).Error())
+}
+
+func Test_warnInvalidFreezeWindows(t *testing.T) {
+	fakeLogger := logger.NewFakeLogger()
+	logger.SetLogger(fakeLogger)
+	defer logger.SetLogger(logger.NewScreenLogger(false))
+	currentContext := &rc.Context{
+		Name: "a",
+		FreezeWindows: []policy.FreezeWindow{
+			{Name: "holidays", Start: "2020-12-24T00:00:00Z", End: "2021-01-04T00:00:00Z"},
+			{Name: "invalid", Start: "2020-12-24", End: "2021-01-04T00:00:00Z"},
+		},
+	}
+
+	warnInvalidFreezeWindows(currentContext)
+
+	require.Len(t, fakeLogger.WarnLogs, 1)
+	assert.Equal(t, `The context "a" has an invalid freeze window "invalid": start must be in RFC3339 format (e.g. "2020-12-24T00:00:00Z"). It is treated as active for the environments that it applies to.`, fakeLogger.WarnLogs[0])
+}
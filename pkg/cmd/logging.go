@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"riser/pkg/logger"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	logFormatHuman = "human"
+	logFormatJson  = "json"
+)
+
+var logFormat string
+var logFilePath string
+
+func addLoggingFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&logFormat, "log-format", logFormatHuman, fmt.Sprintf("The format of log messages (%s or %s). JSON logs are written to stderr so that they do not interfere with command output", logFormatHuman, logFormatJson))
+	_ = cmd.RegisterFlagCompletionFunc("log-format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{logFormatHuman, logFormatJson}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.PersistentFlags().StringVar(&logFilePath, "log-file", "", "Append JSON log messages to a file in addition to the screen. Verbose messages are always written to the log file")
+}
+
+// newLoggers creates the logger and the diagnostic logger for a command based on the logging flags. Diagnostic messages
+// are always written to stderr.
+func newLoggers(cmd *cobra.Command) (logger.Logger, logger.Logger, error) {
+	var screenLogger, diagnosticScreenLogger logger.Logger
+	switch logFormat {
+	case logFormatHuman:
+		screenLogger = logger.NewScreenLogger(verbose)
+		diagnosticScreenLogger = &logger.ScreenLogger{VerboseMode: verbose, Out: os.Stderr}
+	case logFormatJson:
+		screenLogger = logger.NewJSONLogger(os.Stderr, commandName(cmd), verbose)
+		diagnosticScreenLogger = screenLogger
+	default:
+		return nil, nil, fmt.Errorf("Invalid log format %q. Must be one of: %s, %s", logFormat, logFormatHuman, logFormatJson)
+	}
+
+	if logFilePath == "" {
+		return screenLogger, diagnosticScreenLogger, nil
+	}
+
+	logFile, err := os.OpenFile(logFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileLogger := logger.NewJSONLogger(logFile, commandName(cmd), true)
+	return logger.MultiLogger{screenLogger, fileLogger}, logger.MultiLogger{diagnosticScreenLogger, fileLogger}, nil
+}
+
+// commandName returns the command path without the directory of the riser binary (e.g. "riser deploy")
+func commandName(cmd *cobra.Command) string {
+	rootName := cmd.Root().Name()
+	return filepath.Base(rootName) + strings.TrimPrefix(cmd.CommandPath(), rootName)
+}
@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"fmt"
 	"riser/pkg/logger"
 	"riser/pkg/rc"
 	"riser/pkg/ui"
 
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/riser-platform/riser-server/pkg/sdk"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +19,7 @@ func newNamespacesCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command
 
 	cmd.AddCommand(newNamespacesCreateCommand(runtimeConfig))
 	cmd.AddCommand(newNamespacesListCommand(runtimeConfig))
+	cmd.AddCommand(newNamespacesDescribeCommand(runtimeConfig))
 	return cmd
 }
 
@@ -36,6 +40,7 @@ func newNamespacesCreateCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.C
 }
 
 func newNamespacesListCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
+	options := &listOptions{}
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all namespaces",
@@ -44,19 +49,92 @@ func newNamespacesListCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Com
 			riserClient := getRiserClient(currentContext)
 			namespaces, err := riserClient.Namespaces.List()
 			ui.ExitIfErrorMsg(err, "error listing namespaces")
+			apps, err := riserClient.Apps.List()
+			ui.ExitIfErrorMsg(err, "error listing apps")
+
+			appCounts := map[model.NamespaceName]int{}
+			for _, app := range apps {
+				appCounts[app.Namespace]++
+			}
 
 			view := &ui.BasicTableView{}
-			view.Header("Name")
+			view.Header("Name", "Apps")
 
 			for _, ns := range namespaces {
-				view.AddRow(ns.Name)
+				view.AddRow(ns.Name, appCounts[ns.Name])
 			}
 
+			ui.ExitIfError(options.apply(view))
 			ui.RenderView(view)
 		},
 	}
 
+	addListFlags(cmd.Flags(), options)
 	addOutputFlag(cmd.Flags())
 
 	return cmd
 }
+
+func newNamespacesDescribeCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "describe (namespace name)",
+		Short:             "Describes a namespace and the number of deployments for each of its apps by environment",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeNamespaceNames(runtimeConfig)),
+		Run: func(_ *cobra.Command, args []string) {
+			namespaceName := args[0]
+			currentContext := safeCurrentContext(runtimeConfig)
+			riserClient := getRiserClient(currentContext)
+
+			apps, err := getNamespaceApps(riserClient, namespaceName)
+			ui.ExitIfError(err)
+
+			environments, err := riserClient.Environments.List()
+			ui.ExitIfErrorMsg(err, "error listing environments")
+
+			appStatuses := map[string]*model.AppStatus{}
+			for _, app := range apps {
+				appStatus, err := riserClient.Apps.GetStatus(string(app.Name), namespaceName)
+				ui.ExitIfErrorMsg(err, fmt.Sprintf("Error getting status for app %q", app.Name))
+				appStatuses[string(app.Name)] = appStatus
+			}
+
+			ui.RenderView(newNamespacesDescribeView(namespaceName, environments, apps, appStatuses))
+		},
+	}
+
+	addOutputFlag(cmd.Flags())
+
+	return cmd
+}
+
+// getNamespaceApps returns the apps in a namespace. Returns an error if the namespace does not exist.
+func getNamespaceApps(riserClient *sdk.Client, namespaceName string) ([]model.App, error) {
+	namespaces, err := riserClient.Namespaces.List()
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, namespace := range namespaces {
+		if string(namespace.Name) == namespaceName {
+			found = true
+		}
+	}
+	if !found {
+		return nil, ui.NewError(ui.ErrorCodeNotFound, fmt.Sprintf("The namespace %q does not exist", namespaceName))
+	}
+
+	apps, err := riserClient.Apps.List()
+	if err != nil {
+		return nil, err
+	}
+
+	namespaceApps := []model.App{}
+	for _, app := range apps {
+		if string(app.Namespace) == namespaceName {
+			namespaceApps = append(namespaceApps, app)
+		}
+	}
+	return namespaceApps, nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"riser/pkg/ui"
+	"riser/pkg/ui/table"
+	"sort"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+)
+
+type namespacesDescribeView struct {
+	namespaceName string
+	environments  []string
+	apps          []namespaceAppDescribeModel
+}
+
+type namespaceDescribeModel struct {
+	Name string                      `json:"name"`
+	Apps []namespaceAppDescribeModel `json:"apps"`
+}
+
+type namespaceAppDescribeModel struct {
+	Name string `json:"name"`
+	Id   string `json:"id"`
+	// Deployments is the number of deployments by environment name
+	Deployments map[string]int `json:"deployments"`
+}
+
+// newNamespacesDescribeView creates a view from the apps in the namespace and the status of each app by app name
+func newNamespacesDescribeView(namespaceName string, environments []model.EnvironmentMeta, apps []model.App, appStatuses map[string]*model.AppStatus) *namespacesDescribeView {
+	view := &namespacesDescribeView{namespaceName: namespaceName, environments: []string{}, apps: []namespaceAppDescribeModel{}}
+	for _, environment := range environments {
+		view.environments = append(view.environments, environment.Name)
+	}
+	sort.Strings(view.environments)
+
+	for _, app := range apps {
+		view.apps = append(view.apps, namespaceAppDescribeModel{
+			Name:        string(app.Name),
+			Id:          app.Id.String(),
+			Deployments: countDeploymentsByEnvironment(appStatuses[string(app.Name)]),
+		})
+	}
+	sort.Slice(view.apps, func(i, j int) bool {
+		return view.apps[i].Name < view.apps[j].Name
+	})
+
+	return view
+}
+
+func (view *namespacesDescribeView) RenderHuman(writer io.Writer) error {
+	outStr := fmt.Sprintf("Name: %s\n", view.namespaceName)
+	outStr += fmt.Sprintf("Apps: %d\n", len(view.apps))
+
+	if len(view.apps) > 0 {
+		header := append([]string{"App", "Id"}, view.environments...)
+		appsTable := table.Default().Header(header...)
+		for _, app := range view.apps {
+			row := []string{app.Name, app.Id}
+			for _, environment := range view.environments {
+				row = append(row, fmt.Sprintf("%d", app.Deployments[environment]))
+			}
+			appsTable.AddRow(row...)
+		}
+		outStr += "\nDeployments by environment:\n"
+		outStr += appsTable.String() + "\n"
+	}
+
+	_, err := writer.Write([]byte(outStr))
+	return err
+}
+
+func (view *namespacesDescribeView) RenderJson(writer io.Writer) error {
+	return ui.RenderJson(&namespaceDescribeModel{Name: view.namespaceName, Apps: view.apps}, writer)
+}
+
+// countDeploymentsByEnvironment counts the unique deployments in each environment
+func countDeploymentsByEnvironment(appStatus *model.AppStatus) map[string]int {
+	counts := map[string]int{}
+	if appStatus == nil {
+		return counts
+	}
+
+	seen := map[string]bool{}
+	for _, deployment := range appStatus.Deployments {
+		key := deployment.EnvironmentName + "/" + deployment.DeploymentName
+		if !seen[key] {
+			seen[key] = true
+			counts[deployment.EnvironmentName]++
+		}
+	}
+	return counts
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_namespacesDescribeView(t *testing.T) {
+	environments := []model.EnvironmentMeta{{Name: "prod"}, {Name: "dev"}}
+	apps := []model.App{
+		{Id: uuid.New(), Name: "b", Namespace: "myns"},
+		{Id: uuid.New(), Name: "a", Namespace: "myns"},
+	}
+	appStatuses := map[string]*model.AppStatus{
+		"a": {
+			Deployments: []model.DeploymentStatus{
+				{DeploymentName: "a", EnvironmentName: "dev", RiserRevision: 1},
+				{DeploymentName: "a", EnvironmentName: "dev", RiserRevision: 2},
+				{DeploymentName: "a-pr-1", EnvironmentName: "dev"},
+				{DeploymentName: "a", EnvironmentName: "prod"},
+			},
+		},
+		"b": {},
+	}
+
+	view := newNamespacesDescribeView("myns", environments, apps, appStatuses)
+
+	assert.Equal(t, []string{"dev", "prod"}, view.environments)
+	require.Len(t, view.apps, 2)
+	assert.Equal(t, "a", view.apps[0].Name)
+	assert.Equal(t, map[string]int{"dev": 2, "prod": 1}, view.apps[0].Deployments)
+	assert.Equal(t, "b", view.apps[1].Name)
+	assert.Empty(t, view.apps[1].Deployments)
+
+	var b bytes.Buffer
+	require.NoError(t, view.RenderJson(&b))
+	result := &namespaceDescribeModel{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), result))
+	assert.Equal(t, "myns", result.Name)
+	assert.Equal(t, view.apps, result.Apps)
+}
@@ -1,9 +1,17 @@
 package cmd
 
 import (
+	"crypto/ed25519"
 	"crypto/rand"
+	"encoding/base64"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
 	"riser/pkg/ui"
+	"riser/pkg/upgrade"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -15,6 +23,8 @@ func newOpsCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(newGenerateApikeyCommand())
+	cmd.AddCommand(newGenerateReleaseKeyCommand())
+	cmd.AddCommand(newSignReleaseCommand())
 
 	return cmd
 }
@@ -33,3 +43,65 @@ func newGenerateApikeyCommand() *cobra.Command {
 		},
 	}
 }
+
+func newGenerateReleaseKeyCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "generate-release-key",
+		Short: "Generates a key pair for signing riser releases. See \"riser ops sign-release\".",
+		Run: func(cmd *cobra.Command, args []string) {
+			publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+			ui.ExitIfErrorMsg(err, "Error generating release key")
+
+			fmt.Printf("Public key: %s\nPrivate key: %s\n", base64.StdEncoding.EncodeToString(publicKey), base64.StdEncoding.EncodeToString(privateKey))
+		},
+	}
+}
+
+var releaseArtifactExp = regexp.MustCompile(`^riser-([a-z0-9]+)-([a-z0-9]+)\.zip$`)
+
+func newSignReleaseCommand() *cobra.Command {
+	var releaseVersion string
+	var privateKeyFile string
+	var baseURL string
+	cmd := &cobra.Command{
+		Use:     "sign-release (artifact0) [artifactN...]",
+		Short:   "Generates a signed release manifest for \"riser upgrade\"",
+		Long:    "Generates a signed release manifest for \"riser upgrade\". Artifacts must be named in the format \"riser-(os)-(arch).zip\" as produced by \"make release\".",
+		Example: "  riser ops sign-release --version 0.0.50 --private-key-file release.key --base-url https://example.com/releases/0.0.50 riser-*.zip > riser-manifest.json",
+		Args:    cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			encodedKey, err := ioutil.ReadFile(privateKeyFile)
+			ui.ExitIfErrorMsg(err, "Error reading private key")
+			privateKey, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encodedKey)))
+			ui.ExitIfErrorMsg(err, "Invalid private key")
+			if len(privateKey) != ed25519.PrivateKeySize {
+				ui.ExitErrorMsg(fmt.Sprintf("Invalid private key: must be %d bytes", ed25519.PrivateKeySize))
+			}
+
+			manifest := &upgrade.Manifest{Version: releaseVersion, Artifacts: []upgrade.Artifact{}}
+			for _, artifactPath := range args {
+				artifactName := filepath.Base(artifactPath)
+				platform := releaseArtifactExp.FindStringSubmatch(artifactName)
+				if platform == nil {
+					ui.ExitErrorMsg(fmt.Sprintf("Artifact %q must be named in the format \"riser-(os)-(arch).zip\"", artifactName))
+				}
+				artifactBytes, err := ioutil.ReadFile(artifactPath)
+				ui.ExitIfErrorMsg(err, "Error reading artifact")
+
+				manifest.Artifacts = append(manifest.Artifacts,
+					upgrade.SignArtifact(artifactBytes, privateKey, releaseVersion, platform[1], platform[2], fmt.Sprintf("%s/%s", strings.TrimSuffix(baseURL, "/"), artifactName)))
+			}
+
+			ui.ExitIfError(ui.RenderJson(manifest, os.Stdout))
+		},
+	}
+
+	cmd.Flags().StringVar(&releaseVersion, "version", "", "The version of the release")
+	cmd.Flags().StringVar(&privateKeyFile, "private-key-file", "", "Path to a file containing the base64 encoded ed25519 private key")
+	cmd.Flags().StringVar(&baseURL, "base-url", "", "The base URL that the artifacts are downloaded from")
+	_ = cmd.MarkFlagRequired("version")
+	_ = cmd.MarkFlagRequired("private-key-file")
+	_ = cmd.MarkFlagRequired("base-url")
+
+	return cmd
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"riser/pkg/logger"
+	"riser/pkg/policy"
+	"riser/pkg/ui"
+	"strings"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/spf13/pflag"
+)
+
+// addSkipPolicyFlag adds the --skip-policy flag
+func addSkipPolicyFlag(flags *pflag.FlagSet, skipPolicy *[]string) {
+	flags.StringSliceVar(skipPolicy, "skip-policy", []string{}, fmt.Sprintf("Skips the policy rule with this id. The policy file (%s) is in the same directory as the app config.",
+		strings.Join(policy.DefaultPolicyFileNames, " or ")))
+}
+
+// checkPolicy checks the app against the policy file in the same directory as the app config, if one exists
+func checkPolicy(appFilePath string, app *model.AppConfigWithOverrides, environmentName, dockerTag string, skipPolicy []string) error {
+	appPolicy, policyPath, err := policy.Load(filepath.Dir(appFilePath))
+	if err != nil {
+		return &ui.Error{Code: ui.ErrorCodeValidation, Message: err.Error(), Err: err}
+	}
+	if appPolicy == nil {
+		if len(skipPolicy) > 0 {
+			return ui.NewError(ui.ErrorCodeUsage, "--skip-policy was specified but there is no policy file")
+		}
+		return nil
+	}
+
+	for _, ruleId := range skipPolicy {
+		if !appPolicy.HasRule(ruleId) {
+			return ui.NewError(ui.ErrorCodeUsage, fmt.Sprintf("Unknown policy rule %q in --skip-policy", ruleId))
+		}
+		logger.WithFields(logger.Fields{"rule": ruleId, "policy": policyPath, "environment": environmentName}).
+			Warn(fmt.Sprintf("Skipping policy rule %q", ruleId))
+	}
+
+	violations, err := appPolicy.Check(app, environmentName, dockerTag, skipPolicy)
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("Policy check failed (%s):", policyPath)
+	for _, violation := range violations {
+		message += fmt.Sprintf("\n  %s: %s", violation.RuleId, violation.Message)
+	}
+	return &ui.Error{Code: ui.ErrorCodeValidation, Message: message, Details: violations}
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"riser/pkg/ui"
+	"testing"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkPolicy(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "riser-policy")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	appFilePath := filepath.Join(tmpDir, "app.yaml")
+	app := &model.AppConfigWithOverrides{}
+
+	// No policy file
+	assert.NoError(t, checkPolicy(appFilePath, app, "prod", "latest", nil))
+	assert.Equal(t, "--skip-policy was specified but there is no policy file", checkPolicy(appFilePath, app, "prod", "latest", []string{"no-latest"}).Error())
+
+	policyPath := filepath.Join(tmpDir, ".riser-policy.yaml")
+	require.NoError(t, ioutil.WriteFile(policyPath, []byte("rules:\n  - id: no-latest\n    type: disallowedTags\n    environments: [prod]\n"), 0644))
+
+	assert.NoError(t, checkPolicy(appFilePath, app, "dev", "latest", nil))
+	assert.NoError(t, checkPolicy(appFilePath, app, "prod", "latest", []string{"no-latest"}))
+
+	err = checkPolicy(appFilePath, app, "prod", "latest", []string{"foo"})
+	assert.Equal(t, `Unknown policy rule "foo" in --skip-policy`, err.Error())
+	assert.Equal(t, ui.ErrorCodeUsage, ui.ClassifyError(err).Code)
+
+	err = checkPolicy(appFilePath, app, "prod", "latest", nil)
+	assert.Equal(t, "Policy check failed ("+policyPath+"):\n  no-latest: the docker tag \"latest\" is not allowed", err.Error())
+	assert.Equal(t, ui.ErrorCodeValidation, ui.ClassifyError(err).Code)
+}
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
+	"riser/pkg/logger"
 	"riser/pkg/rc"
 	"riser/pkg/ui"
 
@@ -11,11 +13,14 @@ import (
 func newRolloutCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
 	var deploymentName string
 	var namespace string
+	var appFilePath string
+	guardOptions := &environmentGuardOptions{}
 	cmd := &cobra.Command{
-		Use:   "rollout (targetEnvironment) (trafficRule0) [trafficRuleN...]",
-		Short: "Manually controls traffic for a deployment's rollout",
-		Long:  "Manually controls traffic for a deployment's rollout. Typically only used when a deployment is deployed with the \"--manual-rollout\" flag. Traffic rules are in the format \"r(rev#):(traffic%)\" where \"rev\" is the riser revision as shown in \"riser status\"",
-		Args:  cobra.MinimumNArgs(2),
+		Use:               "rollout (targetEnvironment) (trafficRule0) [trafficRuleN...]",
+		ValidArgsFunction: completeArgs(completeEnvironmentNames(runtimeConfig)),
+		Short:             "Manually controls traffic for a deployment's rollout",
+		Long:              "Manually controls traffic for a deployment's rollout. Typically only used when a deployment is deployed with the \"--manual-rollout\" flag. Traffic rules are in the format \"r(rev#):(traffic%)\" where \"rev\" is the riser revision as shown in \"riser status\"",
+		Args:              cobra.MinimumNArgs(2),
 		Example: `  riser rollout prod r1:90 r2:10	// Canary routing 10% of traffic to a new revision
   riser rollout prod r2:100		// Route all traffic to rev 2
   riser rollout prod r2:10 r1:*		// Route 10% of traffic to r2, and the rest to r1
@@ -23,8 +28,12 @@ func newRolloutCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			currentContext := safeCurrentContext(runtimeConfig)
 			environmentName := args[0]
+			_, err := guardEnvironment(currentContext, filepath.Dir(appFilePath), environmentName, guardOptions,
+				logger.Fields{"command": commandName(cmd), "deployment": deploymentName, "namespace": namespace, "trafficRules": args[1:]})
+			ui.ExitIfError(err)
+
 			riserClient := getRiserClient(currentContext)
-			err := riserClient.Rollouts.Save(deploymentName, namespace, environmentName, args[1:]...)
+			err = riserClient.Rollouts.Save(deploymentName, namespace, environmentName, args[1:]...)
 			ui.ExitIfError(err)
 			fmt.Println("Rollout requested")
 		},
@@ -32,5 +41,7 @@ func newRolloutCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
 
 	addDeploymentNameFlag(cmd.Flags(), &deploymentName)
 	addNamespaceFlag(cmd.Flags(), &namespace)
+	addEnvironmentGuardFlags(cmd.Flags(), guardOptions)
+	addAppFilePathFlag(cmd.Flags(), &appFilePath)
 	return cmd
 }
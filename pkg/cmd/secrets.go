@@ -23,10 +23,11 @@ func newSecretsSaveCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Comman
 	var appName string
 	var namespace string
 	cmd := &cobra.Command{
-		Use:   "save (name) (plaintextsecret) (targetEnvironment)",
-		Short: "Creates or updates a secret",
-		Long:  "Creates or updates a secret. Secrets are stored seperately per app and environment.",
-		Args:  cobra.ExactArgs(3),
+		Use:               "save (name) (plaintextsecret) (targetEnvironment)",
+		ValidArgsFunction: completeArgs(nil, nil, completeEnvironmentNames(runtimeConfig)),
+		Short:             "Creates or updates a secret",
+		Long:              "Creates or updates a secret. Secrets are stored seperately per app and environment.",
+		Args:              cobra.ExactArgs(3),
 		Run: func(cmd *cobra.Command, args []string) {
 			currentContext := safeCurrentContext(runtimeConfig)
 			secretName := args[0]
@@ -51,9 +52,10 @@ func newSecretsListCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Comman
 	var appName string
 	var namespace string
 	cmd := &cobra.Command{
-		Use:   "list (environment)",
-		Short: "Lists secrets configured for a given environment",
-		Args:  cobra.ExactArgs(1),
+		Use:               "list (environment)",
+		ValidArgsFunction: completeArgs(completeEnvironmentNames(runtimeConfig)),
+		Short:             "Lists secrets configured for a given environment",
+		Args:              cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			currentContext := safeCurrentContext(runtimeConfig)
 			environmentName := args[0]
@@ -3,11 +3,11 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"riser/pkg/docker"
 	"riser/pkg/status"
 	"riser/pkg/ui"
 	"riser/pkg/ui/style"
 	"riser/pkg/ui/table"
-	"strings"
 
 	"github.com/riser-platform/riser-server/api/v1/model"
 )
@@ -19,12 +19,24 @@ type statusView struct {
 }
 
 func (view *statusView) RenderHuman(writer io.Writer) error {
+	return view.render(writer, false)
+}
+
+// RenderWide additionally renders the revision name and the full docker image
+func (view *statusView) RenderWide(writer io.Writer) error {
+	return view.render(writer, true)
+}
+
+func (view *statusView) render(writer io.Writer, wide bool) error {
 	outStr := ""
 	if len(view.status.Deployments) == 0 {
 		outStr += fmt.Sprintf("There are no deployments for the app %q. Use \"riser deploy\" to make your first deployment.\n", view.appName)
 	} else {
-
-		statusTable := table.Default().Header("Deployment", "Env", "Traffic", "Rev", "Docker Tag", "Status", "Reason")
+		header := []string{"Deployment", "Env", "Traffic", "Rev", "Docker Tag", "Status", "Reason"}
+		if wide {
+			header = append(header, "Revision Name", "Docker Image")
+		}
+		statusTable := table.Default().Header(header...)
 
 		for _, deploymentStatus := range view.status.Deployments {
 			revisions := status.GetRevisionStatus(&deploymentStatus, view.activeRevisionsOnly)
@@ -32,26 +44,21 @@ func (view *statusView) RenderHuman(writer io.Writer) error {
 			if len(revisions) > 0 {
 				first := true
 				for _, activeRevision := range revisions {
+					row := []string{"", ""}
 					if first {
-						statusTable.AddRow(
-							deploymentStatus.DeploymentName,
-							deploymentStatus.EnvironmentName,
-							formatTraffic(&activeRevision.Traffic),
-							fmt.Sprintf("%d", activeRevision.RiserRevision),
-							formatDockerTag(activeRevision.DockerImage),
-							formatRevisionStatus(activeRevision.RevisionStatus),
-							activeRevision.RevisionStatusReason,
-						)
-					} else {
-						statusTable.AddRow(
-							"", "",
-							formatTraffic(&activeRevision.Traffic),
-							fmt.Sprintf("%d", activeRevision.RiserRevision),
-							formatDockerTag(activeRevision.DockerImage),
-							formatRevisionStatus(activeRevision.RevisionStatus),
-							activeRevision.RevisionStatusReason,
-						)
+						row = []string{deploymentStatus.DeploymentName, deploymentStatus.EnvironmentName}
 					}
+					row = append(row,
+						formatTraffic(&activeRevision.Traffic),
+						fmt.Sprintf("%d", activeRevision.RiserRevision),
+						formatDockerTag(activeRevision.DockerImage),
+						formatRevisionStatus(activeRevision.RevisionStatus),
+						activeRevision.RevisionStatusReason,
+					)
+					if wide {
+						row = append(row, activeRevision.Name, activeRevision.DockerImage)
+					}
+					statusTable.AddRow(row...)
 					first = false
 				}
 			}
@@ -84,11 +91,28 @@ func formatTraffic(traffic *model.DeploymentTrafficStatus) string {
 }
 
 func formatDockerTag(dockerImage string) string {
-	idx := strings.Index(dockerImage, ":")
-	if idx == -1 {
+	tag := dockerTag(dockerImage)
+	switch {
+	case tag == "":
 		return style.Warn("Unknown")
+	case docker.IsDigest(tag):
+		// Abbreviate digests similar to "docker images". The server may report digests that are not valid.
+		if len(tag) > len("sha256:")+12 {
+			tag = tag[:len("sha256:")+12]
+		}
+		return docker.DigestPrefix + tag
+	}
+	return tag
+}
+
+// dockerTag returns the tag of a docker image, or the digest when the image does not have a tag. Returns an empty string
+// if the image has neither.
+func dockerTag(dockerImage string) string {
+	ref := docker.ParseReference(dockerImage)
+	if ref.Tag == "" {
+		return ref.Digest
 	}
-	return dockerImage[idx+1:]
+	return ref.Tag
 }
 
 func formatRevisionStatus(rolloutStatus string) string {
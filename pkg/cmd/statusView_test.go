@@ -30,7 +30,11 @@ func Test_formatDockerTag(t *testing.T) {
 		expected    string
 	}{
 		{"foo:v1", "v1"},
+		{"localhost:5000/foo:v1", "v1"},
+		{"foo@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", "@sha256:0123456789ab"},
+		{"foo@sha256:abc", "@sha256:abc"},
 		{"foo", style.Warn("Unknown")},
+		{"localhost:5000/foo", style.Warn("Unknown")},
 	}
 
 	for _, tt := range tests {
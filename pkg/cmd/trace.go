@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"net/http"
+	"net/url"
+	"riser/pkg/rc"
+	"riser/pkg/trace"
+
+	"github.com/hashicorp/go-version"
+	"github.com/spf13/cobra"
+)
+
+var traceEnabled bool
+var traceHarPath string
+
+// tracer is nil unless tracing is enabled
+var tracer *trace.Transport
+
+func addTraceFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolVar(&traceEnabled, "trace", false, "Log each HTTP request and response to stderr. Sensitive values such as the API key are redacted")
+	cmd.PersistentFlags().StringVar(&traceHarPath, "trace-har", "", "Record each HTTP request and response to a HAR file. Sensitive values such as the API key are redacted")
+}
+
+// enableTracing replaces the default HTTP transport with a tracing transport. The SDK client uses the default transport.
+func enableTracing(currentVersion *version.Version) {
+	if !traceEnabled && traceHarPath == "" {
+		return
+	}
+
+	tracer = trace.NewTransport()
+	tracer.Log = traceEnabled
+	if traceHarPath != "" {
+		tracer.HAR = trace.NewHARRecorder(traceHarPath, currentVersion.String())
+	}
+	http.DefaultTransport = tracer
+}
+
+// traceContext ensures that the context's API key is redacted and that TLS verification is disabled for insecure contexts
+func traceContext(c *rc.Context) error {
+	tracer.Redactor.AddSecret(c.Apikey)
+	if !c.IsSecure() {
+		serverURL, err := url.Parse(c.ServerURL)
+		if err != nil {
+			return err
+		}
+		tracer.AddInsecureHost(serverURL.Host)
+	}
+	return nil
+}
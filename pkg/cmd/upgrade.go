@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"riser/pkg/logger"
+	"riser/pkg/ui"
+	"riser/pkg/ui/style"
+	"riser/pkg/upgrade"
+	"runtime"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/spf13/cobra"
+)
+
+const manifestURLEnvVar = "RISER_RELEASE_MANIFEST_URL"
+
+func newUpgradeCommand(currentVersion *version.Version) *cobra.Command {
+	var manifestURL string
+	checkOnly := false
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrades riser to the latest release",
+		Long:  "Upgrades riser to the latest release. The release is verified using its checksum and the signature of the public key that this version of riser was released with before the running riser binary is replaced.",
+		Example: `  riser upgrade           // Upgrade to the latest release
+  riser upgrade --check   // Exits with a non-zero exit code if a newer release is available`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			manifest, err := upgrade.FetchManifest(manifestURL)
+			ui.ExitIfError(err)
+
+			latestVersion, err := manifest.ParsedVersion()
+			ui.ExitIfErrorMsg(err, "Invalid release version")
+
+			if !latestVersion.GreaterThan(currentVersion) {
+				logger.Log().Info(fmt.Sprintf("riser %s is up to date", currentVersion))
+				return
+			}
+
+			if checkOnly {
+				ui.ExitErrorMsg(fmt.Sprintf("A newer version of riser is available: %s (current: %s). Use \"riser upgrade\" to upgrade.", latestVersion, currentVersion))
+			}
+
+			decodedPublicKey, err := upgrade.DecodePublicKey(upgrade.ReleasePublicKey())
+			ui.ExitIfErrorMsg(err, "Unable to verify the release")
+
+			artifact, err := manifest.Artifact(runtime.GOOS, runtime.GOARCH)
+			ui.ExitIfError(err)
+
+			logger.Log().Info(fmt.Sprintf("Downloading riser %s...", latestVersion))
+			artifactBytes, err := upgrade.DownloadArtifact(manifest.Version, artifact, decodedPublicKey)
+			ui.ExitIfError(err)
+
+			binary, err := upgrade.ExtractBinary(artifactBytes)
+			ui.ExitIfError(err)
+
+			executablePath, err := os.Executable()
+			ui.ExitIfErrorMsg(err, "Unable to determine the path of the riser binary")
+			executablePath, err = filepath.EvalSymlinks(executablePath)
+			ui.ExitIfErrorMsg(err, "Unable to determine the path of the riser binary")
+
+			err = upgrade.ReplaceExecutable(executablePath, binary)
+			ui.ExitIfErrorMsg(err, "Error replacing the riser binary")
+
+			logger.Log().Info(style.Good(fmt.Sprintf("Upgraded riser from %s to %s", currentVersion, latestVersion)))
+		},
+	}
+
+	defaultManifestURL := os.Getenv(manifestURLEnvVar)
+	if defaultManifestURL == "" {
+		defaultManifestURL = upgrade.DefaultManifestURL
+	}
+
+	cmd.Flags().StringVar(&manifestURL, "manifest-url", defaultManifestURL, fmt.Sprintf("The URL of the release manifest. May also be set with the %s environment variable", manifestURLEnvVar))
+	cmd.Flags().BoolVar(&checkOnly, "check", false, "Only check for a newer release. Exits with a non-zero exit code if a newer release is available")
+
+	return cmd
+}
@@ -21,7 +21,10 @@ func getRiserClient(c *rc.Context) *sdk.Client {
 	client, err := sdk.NewClient(c.ServerURL, c.Apikey)
 	ui.ExitIfErrorMsg(err, "Error instantiating riser SDK")
 
-	if c.Secure != nil && !*c.Secure {
+	if tracer != nil {
+		// MakeInsecure replaces the default transport so the tracer handles insecure contexts instead
+		ui.ExitIfErrorMsg(traceContext(c), "Error enabling tracing")
+	} else if c.Secure != nil && !*c.Secure {
 		client.MakeInsecure()
 	}
 	return client
@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 	"riser/pkg/config"
-	"riser/pkg/logger"
 	"riser/pkg/rc"
 	"riser/pkg/ui"
 
@@ -12,26 +11,33 @@ import (
 
 func newValidateCommand(runtimeConfig *rc.RuntimeConfiguration) *cobra.Command {
 	var appFilePath string
+	var environmentName string
+	var skipPolicy []string
 	cmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validates an app config",
+		Long: "Validates an app config and checks it against the policy file, if one exists. Use --environment to check the policy rules " +
+			"for an environment using the app config's environment overrides. Otherwise only rules that apply to all environments are checked.",
 		Run: func(cmd *cobra.Command, args []string) {
 			currentContext := safeCurrentContext(runtimeConfig)
 			app, err := config.LoadAppFromConfig(appFilePath)
-			if err == nil {
-				riserClient := getRiserClient(currentContext)
+			ui.ExitIfErrorMsg(err, fmt.Sprintf("Failed to load app config %s", appFilePath))
 
-				err := riserClient.Validate.AppConfig(app)
-				ui.ExitIfError(err)
+			riserClient := getRiserClient(currentContext)
 
-				fmt.Println("App config is valid")
-			} else {
-				logger.Log().Error(fmt.Sprintf("Failed to load app config %s: %s", appFilePath, err))
-			}
+			err = riserClient.Validate.AppConfig(app)
+			ui.ExitIfError(err)
+
+			ui.ExitIfError(checkPolicy(appFilePath, app, environmentName, "", skipPolicy))
+
+			fmt.Println("App config is valid")
 		},
 	}
 
 	addAppFilePathFlag(cmd.Flags(), &appFilePath)
+	addSkipPolicyFlag(cmd.Flags(), &skipPolicy)
+	cmd.Flags().StringVar(&environmentName, "environment", "", "Checks the policy rules for this environment")
+	_ = cmd.RegisterFlagCompletionFunc("environment", completeEnvironmentNames(runtimeConfig))
 
 	return cmd
 }
@@ -0,0 +1,91 @@
+// Package completion provides support for dynamic shell completion
+package completion
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+)
+
+// DefaultTTL is short since completions should reflect recent changes while still avoiding a request on every keystroke
+const DefaultTTL = 30 * time.Second
+
+// Cache caches completion values on disk. Completion runs in a new process on each request so an in-memory cache is of no use.
+type Cache struct {
+	dir string
+	ttl time.Duration
+	// now is a func so that it can be stubbed in tests
+	now func() time.Time
+}
+
+// NewCache creates a new cache in the specified directory
+func NewCache(dir string, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// DefaultDir returns the default cache directory for completions (e.g. ~/.cache/riser/completion on Linux)
+func DefaultDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(cacheDir, "riser", "completion"), nil
+}
+
+// Get returns the cached values for the key if they have not expired. Otherwise the values are fetched and cached.
+// Errors writing to the cache are ignored since the cache is only an optimization.
+func (cache *Cache) Get(key string, fetch func() ([]string, error)) ([]string, error) {
+	cachePath := cache.path(key)
+	if values, ok := cache.read(cachePath); ok {
+		return values, nil
+	}
+
+	values, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	cache.write(cachePath, values)
+	return values, nil
+}
+
+func (cache *Cache) read(cachePath string) ([]string, bool) {
+	fileInfo, err := os.Stat(cachePath)
+	if err != nil || cache.now().Sub(fileInfo.ModTime()) >= cache.ttl {
+		return nil, false
+	}
+
+	valueBytes, err := ioutil.ReadFile(cachePath)
+	if err != nil {
+		return nil, false
+	}
+
+	values := []string{}
+	if json.Unmarshal(valueBytes, &values) != nil {
+		return nil, false
+	}
+
+	return values, true
+}
+
+func (cache *Cache) write(cachePath string, values []string) {
+	valueBytes, err := json.Marshal(values)
+	if err != nil {
+		return
+	}
+
+	if os.MkdirAll(cache.dir, 0700) != nil {
+		return
+	}
+
+	_ = ioutil.WriteFile(cachePath, valueBytes, 0600)
+}
+
+// path hashes the key since keys may contain characters that are not valid in a file name (e.g. a server URL)
+func (cache *Cache) path(key string) string {
+	return path.Join(cache.dir, fmt.Sprintf("%x.json", sha256.Sum256([]byte(key))))
+}
@@ -0,0 +1,61 @@
+package completion
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Cache_Get(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "riser-completion")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	cache := NewCache(path.Join(tempDir, "completion"), time.Minute)
+	fetchCount := 0
+	fetch := func() ([]string, error) {
+		fetchCount++
+		return []string{"dev", "prod"}, nil
+	}
+
+	values, err := cache.Get("environments", fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dev", "prod"}, values)
+
+	values, err = cache.Get("environments", fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"dev", "prod"}, values)
+	assert.Equal(t, 1, fetchCount)
+
+	// Different key
+	_, err = cache.Get("namespaces", fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fetchCount)
+
+	// Expired
+	cache.now = func() time.Time { return time.Now().Add(time.Minute) }
+	_, err = cache.Get("environments", fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fetchCount)
+}
+
+func Test_Cache_Get_FetchError(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "riser-completion")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	cache := NewCache(tempDir, time.Minute)
+
+	values, err := cache.Get("environments", func() ([]string, error) { return nil, errors.New("busted") })
+
+	assert.Nil(t, values)
+	assert.Equal(t, "busted", err.Error())
+	files, _ := ioutil.ReadDir(tempDir)
+	assert.Empty(t, files)
+}
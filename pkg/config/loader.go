@@ -99,6 +99,12 @@ func SafeLoadAppNamespace(pathToAppConfig string) string {
 // Returns the default namespace "apps" if the namespace is not specified, the file does not exist, cannot be be parsed,
 // or if any other error occurs.
 func SafeLoadDefaultAppNamespace() string {
+	return SafeLoadDefaultAppNamespaceWithFallback(DefaultNamespace)
+}
+
+// SafeLoadDefaultAppNamespaceWithFallback is the same as SafeLoadDefaultAppNamespace but returns the fallback namespace
+// instead of the default namespace "apps".
+func SafeLoadDefaultAppNamespaceWithFallback(fallbackNamespace string) string {
 	for _, pathToAppConfig := range DefaultAppConfigPaths {
 		result := SafeLoadAppNamespace(pathToAppConfig)
 		if result != "" {
@@ -106,7 +112,7 @@ func SafeLoadDefaultAppNamespace() string {
 		}
 	}
 
-	return DefaultNamespace
+	return fallbackNamespace
 }
 
 // GetAppConfigPathFromDefaults searches for an app config from the default locations and returns the first found
@@ -2,6 +2,7 @@ package deploy
 
 import (
 	"fmt"
+	"riser/pkg/logger"
 	"time"
 
 	"github.com/pkg/errors"
@@ -9,6 +10,30 @@ import (
 	"github.com/riser-platform/riser-server/pkg/sdk"
 )
 
+// TimeoutError is returned when a revision does not become ready within the timeout
+type TimeoutError struct {
+	Duration time.Duration
+	// Err is the last error observed while waiting
+	Err error
+}
+
+func (err *TimeoutError) Error() string {
+	message := fmt.Sprintf("Timeout of %s exceeded waiting for the new revision to become ready", err.Duration)
+	if err.Err == nil {
+		return message
+	}
+	return fmt.Sprintf("%s: %s", message, err.Err)
+}
+
+func (err *TimeoutError) Unwrap() error {
+	return err.Err
+}
+
+// Timeout allows the error to be classified as a timeout without a dependency on this package
+func (err *TimeoutError) Timeout() bool {
+	return true
+}
+
 type isReadyFunc func(statuses []model.DeploymentStatus, deploymentName string, environmentName string, riserRevision int64) (bool, string)
 
 // WaitForReady waits for a deployment to become ready for a specified riserRevision. It returns an error
@@ -24,14 +49,9 @@ func waitForReady(isReady isReadyFunc, apps sdk.AppsClient, app model.App, deplo
 	start := time.Now()
 
 	go func() {
-		for {
+		for attempt := 1; ; attempt++ {
 			if time.Since(start) >= timeout {
-				err := fmt.Errorf("Timeout of %s exceeded waiting for the new revision to become ready", timeout)
-				if resultErr == nil {
-					resultErr = err
-				} else {
-					resultErr = errors.Wrap(resultErr, err.Error())
-				}
+				resultErr = &TimeoutError{Duration: timeout, Err: resultErr}
 				close(done)
 				break
 			}
@@ -50,6 +70,14 @@ func waitForReady(isReady isReadyFunc, apps sdk.AppsClient, app model.App, deplo
 				break
 			}
 			resultErr = errors.New(fmt.Sprintf("Revision status is %q", reason))
+			logger.WithFields(logger.Fields{
+				"deployment":    deploymentName,
+				"environment":   environmentName,
+				"riserRevision": riserRevision,
+				"attempt":       attempt,
+				"elapsedMs":     time.Since(start).Milliseconds(),
+				"reason":        reason,
+			}).Verbose(fmt.Sprintf("Waiting for revision %d to become ready: %s", riserRevision, reason))
 
 			time.Sleep(1 * time.Second)
 		}
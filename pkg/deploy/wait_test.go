@@ -131,6 +131,9 @@ func Test_waitForReady_ReturnsAppsClientErrorAfterTimeout(t *testing.T) {
 	err := waitForReady(fakeIsReady, apps, app, "mydep", "myenv", 1, 100*time.Millisecond)
 
 	assert.Equal(t, "Timeout of 100ms exceeded waiting for the new revision to become ready: busted", err.Error())
+	var timeoutErr *TimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.True(t, timeoutErr.Timeout())
 }
 
 func Test_isReady(t *testing.T) {
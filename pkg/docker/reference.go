@@ -0,0 +1,44 @@
+// Package docker parses docker image references
+package docker
+
+import (
+	"strings"
+)
+
+// DigestPrefix separates the digest in an image reference (e.g. "myimage@sha256:...")
+const DigestPrefix = "@"
+
+// Reference is a parsed docker image reference
+type Reference struct {
+	// Repository includes the registry host if specified (e.g. "localhost:5000/myapp")
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses a docker image reference (e.g. "localhost:5000/myapp:v1" or "myapp@sha256:...")
+func ParseReference(image string) Reference {
+	ref := Reference{}
+	if idx := strings.LastIndex(image, DigestPrefix); idx != -1 {
+		ref.Digest = image[idx+1:]
+		image = image[:idx]
+	}
+	// A colon after the last slash separates the tag. Colons before it are part of the registry host (e.g. "localhost:5000")
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		ref.Tag = image[idx+1:]
+		image = image[:idx]
+	}
+	ref.Repository = image
+
+	return ref
+}
+
+// IsDigest returns true if the value is a digest, optionally prefixed with "@" (e.g. "@sha256:...")
+func IsDigest(value string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(value, DigestPrefix), "sha256:")
+}
+
+// IsMutableTag returns true if a tag is commonly reused for different images
+func IsMutableTag(tag string) bool {
+	return tag == "latest"
+}
@@ -0,0 +1,34 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testDigest = "sha256:" + strings.Repeat("a", 64)
+
+func Test_ParseReference(t *testing.T) {
+	tests := []struct {
+		image    string
+		expected Reference
+	}{
+		{"nginx", Reference{Repository: "nginx"}},
+		{"nginx:1.19", Reference{Repository: "nginx", Tag: "1.19"}},
+		{"localhost:5000/myapp", Reference{Repository: "localhost:5000/myapp"}},
+		{"localhost:5000/myapp:v1", Reference{Repository: "localhost:5000/myapp", Tag: "v1"}},
+		{"gcr.io/proj/myapp@" + testDigest, Reference{Repository: "gcr.io/proj/myapp", Digest: testDigest}},
+		{"myapp:v1@" + testDigest, Reference{Repository: "myapp", Tag: "v1", Digest: testDigest}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ParseReference(tt.image), tt.image)
+	}
+}
+
+func Test_IsDigest(t *testing.T) {
+	assert.True(t, IsDigest(testDigest))
+	assert.True(t, IsDigest("@"+testDigest))
+	assert.False(t, IsDigest("v1"))
+}
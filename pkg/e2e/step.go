@@ -1,6 +1,8 @@
 package e2e
 
 import (
+	"fmt"
+	"riser/pkg/ui/style"
 	"sync"
 	"testing"
 	"time"
@@ -16,7 +18,7 @@ func Step(t *testing.T, message string, fn func()) {
 	t.Helper()
 	start := time.Now()
 	fn()
-	t.Logf("%s%s (%dms)%s\n", stepColor(t.Name()), message, time.Since(start).Milliseconds(), ctc.Reset)
+	t.Logf("%s\n", style.Colorize(fmt.Sprintf("%s (%dms)", message, time.Since(start).Milliseconds()), stepColor(t.Name())))
 }
 
 var stepColors = map[string]ctc.Color{}
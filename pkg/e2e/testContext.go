@@ -74,8 +74,7 @@ func SetupSingleEnvTestContext(t *testing.T) *SingleEnvTestContext {
 
 	// Validate riser environment is setup
 	err = Retry(func() (bool, error) {
-		// ... grep until we support json output
-		_, err = shell("riser environments list | grep %s", ctx.RiserEnvironment)
+		_, err = shell(`riser environments list -o jsonpath='{range [*]}{.Name}{"\n"}{end}' | grep -x %s`, ctx.RiserEnvironment)
 		return err == nil, err
 	})
 	if err != nil {
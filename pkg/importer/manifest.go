@@ -0,0 +1,573 @@
+// Package importer maps existing Kubernetes workloads to a riser app config
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	knativeServingGroup = "serving.knative.dev"
+	// defaultKnativePort is the port that Knative uses when a container does not specify one
+	defaultKnativePort = 8080
+)
+
+var (
+	envVarKeyPattern           = regexp.MustCompile("^[A-Z][A-Z0-9_]*$")
+	knativeVisibilityLabels    = []string{"networking.knative.dev/visibility", "serving.knative.dev/visibility"}
+	knativeMinScaleAnnotations = []string{"autoscaling.knative.dev/minScale", "autoscaling.knative.dev/min-scale"}
+	knativeMaxScaleAnnotations = []string{"autoscaling.knative.dev/maxScale", "autoscaling.knative.dev/max-scale"}
+)
+
+// Result is an app config imported from a manifest
+type Result struct {
+	// AppConfig does not have an Id or Namespace since they are assigned by riser
+	AppConfig *model.AppConfig
+	// Unmapped describes each part of the manifest that could not be mapped to the app config
+	Unmapped []string
+}
+
+type importer struct {
+	result *Result
+}
+
+// FromManifest imports a manifest containing a single Kubernetes Deployment (with optional Services, Ingresses, and a
+// HorizontalPodAutoscaler) or a single Knative Service. Helm rendered manifests with multiple documents are supported.
+func FromManifest(reader io.Reader) (*Result, error) {
+	objects, err := decodeManifest(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads := []*unstructured.Unstructured{}
+	for _, object := range objects {
+		if isDeployment(object) || isKnativeService(object) {
+			workloads = append(workloads, object)
+		}
+	}
+
+	if len(workloads) != 1 {
+		return nil, fmt.Errorf("the manifest must contain exactly one Deployment or Knative Service (found %d)", len(workloads))
+	}
+
+	i := &importer{result: &Result{AppConfig: &model.AppConfig{}, Unmapped: []string{}}}
+	if isKnativeService(workloads[0]) {
+		err = i.importKnativeService(workloads[0], objects)
+	} else {
+		err = i.importDeployment(workloads[0], objects)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return i.result, nil
+}
+
+func decodeManifest(reader io.Reader) ([]*unstructured.Unstructured, error) {
+	objects := []*unstructured.Unstructured{}
+	decoder := yaml.NewYAMLOrJSONDecoder(reader, 4096)
+	for {
+		raw := json.RawMessage{}
+		err := decoder.Decode(&raw)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "Error parsing manifest")
+		}
+
+		trimmed := bytes.TrimSpace(raw)
+		if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+			// e.g. Helm templates that render an empty document
+			continue
+		}
+
+		decoded, err := runtime.Decode(unstructured.UnstructuredJSONScheme, trimmed)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error parsing manifest")
+		}
+
+		switch object := decoded.(type) {
+		case *unstructured.Unstructured:
+			objects = append(objects, object)
+		case *unstructured.UnstructuredList:
+			for idx := range object.Items {
+				objects = append(objects, &object.Items[idx])
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+func (i *importer) importKnativeService(service *unstructured.Unstructured, objects []*unstructured.Unstructured) error {
+	i.result.AppConfig.Name = model.AppName(service.GetName())
+	i.reportUnmappedObjects(objects, service)
+	i.reportUnmappedFields(fmt.Sprintf("Knative Service %q spec", service.GetName()), service.Object["spec"], "template")
+
+	podTemplate, _, _ := unstructured.NestedMap(service.Object, "spec", "template")
+	container, err := i.importPodTemplate(podTemplate)
+	if err != nil {
+		return err
+	}
+
+	scope := model.AppExposeScope_External
+	for _, label := range knativeVisibilityLabels {
+		if service.GetLabels()[label] == "cluster-local" {
+			scope = model.AppExposeScope_Cluster
+		}
+	}
+
+	port := int64(defaultKnativePort)
+	protocol := "http"
+	ports, _, _ := unstructured.NestedSlice(container, "ports")
+	if len(ports) > 0 {
+		portMap, _ := ports[0].(map[string]interface{})
+		if containerPort, ok, _ := unstructured.NestedInt64(portMap, "containerPort"); ok {
+			port = containerPort
+		}
+		name, _, _ := unstructured.NestedString(portMap, "name")
+		protocol = protocolFromName(name)
+	}
+	i.result.AppConfig.Expose = &model.AppConfigExpose{ContainerPort: int32(port), Protocol: protocol, Scope: scope}
+
+	annotations, _, _ := unstructured.NestedStringMap(podTemplate, "metadata", "annotations")
+	autoscale := &model.AppConfigAutoscale{}
+	for _, key := range sortedKeys(annotations) {
+		value := annotations[key]
+		switch {
+		case containsString(knativeMinScaleAnnotations, key):
+			autoscale.Min = i.parseScale(key, value)
+		case containsString(knativeMaxScaleAnnotations, key):
+			autoscale.Max = i.parseScale(key, value)
+		case strings.HasPrefix(key, "autoscaling.knative.dev/"):
+			i.unmapped("annotation %q: riser only supports min and max scale", key)
+		}
+	}
+	if autoscale.Min != nil || autoscale.Max != nil {
+		i.result.AppConfig.Autoscale = autoscale
+	}
+
+	return nil
+}
+
+func (i *importer) importDeployment(deployment *unstructured.Unstructured, objects []*unstructured.Unstructured) error {
+	i.result.AppConfig.Name = model.AppName(deployment.GetName())
+	i.reportUnmappedFields(fmt.Sprintf("Deployment %q spec", deployment.GetName()), deployment.Object["spec"], "replicas", "selector", "template")
+
+	podTemplate, _, _ := unstructured.NestedMap(deployment.Object, "spec", "template")
+	container, err := i.importPodTemplate(podTemplate)
+	if err != nil {
+		return err
+	}
+
+	podLabels, _, _ := unstructured.NestedStringMap(podTemplate, "metadata", "labels")
+	services := []*unstructured.Unstructured{}
+	var hpa *unstructured.Unstructured
+	imported := []*unstructured.Unstructured{deployment}
+	for _, object := range objects {
+		switch {
+		case object.GetKind() == "Service" && object.GroupVersionKind().Group == "":
+			selector, _, _ := unstructured.NestedStringMap(object.Object, "spec", "selector")
+			if len(selector) > 0 && selectorMatches(selector, podLabels) {
+				services = append(services, object)
+				imported = append(imported, object)
+			}
+		case object.GetKind() == "HorizontalPodAutoscaler":
+			targetName, _, _ := unstructured.NestedString(object.Object, "spec", "scaleTargetRef", "name")
+			if targetName == deployment.GetName() {
+				hpa = object
+				imported = append(imported, object)
+			}
+		}
+	}
+
+	externalServices := map[string]bool{}
+	for _, object := range objects {
+		if object.GetKind() == "Ingress" {
+			for _, serviceName := range i.ingressServiceNames(object) {
+				externalServices[serviceName] = true
+			}
+			i.unmapped("Ingress %q was not imported: external apps are exposed by the riser gateway", object.GetName())
+			imported = append(imported, object)
+		}
+	}
+	i.reportUnmappedObjects(objects, imported...)
+
+	expose := &model.AppConfigExpose{Protocol: "http", Scope: model.AppExposeScope_Cluster}
+	containerPorts, _, _ := unstructured.NestedSlice(container, "ports")
+	if len(services) > 1 {
+		i.unmapped("only the Service %q was imported: riser apps have a single port", services[0].GetName())
+	}
+	if len(services) > 0 {
+		service := services[0]
+		serviceType, _, _ := unstructured.NestedString(service.Object, "spec", "type")
+		if serviceType == "LoadBalancer" || serviceType == "NodePort" || externalServices[service.GetName()] {
+			expose.Scope = model.AppExposeScope_External
+		}
+		servicePorts, _, _ := unstructured.NestedSlice(service.Object, "spec", "ports")
+		if len(servicePorts) > 1 {
+			i.unmapped("Service %q: only the first port was imported", service.GetName())
+		}
+		if len(servicePorts) > 0 {
+			servicePort, _ := servicePorts[0].(map[string]interface{})
+			expose.ContainerPort, expose.Protocol = i.targetPort(servicePort, containerPorts)
+		}
+	} else {
+		i.unmapped("no Service selects the Deployment %q: the app will only be reachable within the cluster", deployment.GetName())
+	}
+
+	if expose.ContainerPort == 0 {
+		if len(containerPorts) == 0 {
+			i.unmapped("no container port found: update expose.containerPort")
+		} else {
+			portMap, _ := containerPorts[0].(map[string]interface{})
+			port, _, _ := unstructured.NestedInt64(portMap, "containerPort")
+			name, _, _ := unstructured.NestedString(portMap, "name")
+			expose.ContainerPort = int32(port)
+			expose.Protocol = protocolFromName(name)
+		}
+	}
+	i.result.AppConfig.Expose = expose
+
+	if hpa != nil {
+		i.importHorizontalPodAutoscaler(hpa)
+	} else if replicas, ok, _ := unstructured.NestedInt64(deployment.Object, "spec", "replicas"); ok {
+		// A fixed number of replicas is the same as autoscaling with equal min and max
+		scale := int(replicas)
+		i.result.AppConfig.Autoscale = &model.AppConfigAutoscale{Min: &scale, Max: &scale}
+	}
+
+	return nil
+}
+
+func (i *importer) importHorizontalPodAutoscaler(hpa *unstructured.Unstructured) {
+	autoscale := &model.AppConfigAutoscale{}
+	if minReplicas, ok, _ := unstructured.NestedInt64(hpa.Object, "spec", "minReplicas"); ok {
+		min := int(minReplicas)
+		autoscale.Min = &min
+	}
+	if maxReplicas, ok, _ := unstructured.NestedInt64(hpa.Object, "spec", "maxReplicas"); ok {
+		max := int(maxReplicas)
+		autoscale.Max = &max
+	}
+	i.result.AppConfig.Autoscale = autoscale
+	i.reportUnmappedFields(fmt.Sprintf("HorizontalPodAutoscaler %q spec", hpa.GetName()), hpa.Object["spec"], "minReplicas", "maxReplicas", "scaleTargetRef")
+}
+
+// targetPort returns the container port and protocol that a Service port targets
+func (i *importer) targetPort(servicePort map[string]interface{}, containerPorts []interface{}) (int32, string) {
+	appProtocol, _, _ := unstructured.NestedString(servicePort, "appProtocol")
+	serviceName, _, _ := unstructured.NestedString(servicePort, "name")
+	protocol := protocolFromName(appProtocol)
+	if protocol == "http" {
+		protocol = protocolFromName(serviceName)
+	}
+
+	target, ok := servicePort["targetPort"]
+	if !ok {
+		target = servicePort["port"]
+	}
+
+	for _, containerPort := range containerPorts {
+		portMap, _ := containerPort.(map[string]interface{})
+		port, _, _ := unstructured.NestedInt64(portMap, "containerPort")
+		name, _, _ := unstructured.NestedString(portMap, "name")
+		if target == port || (name != "" && target == name) {
+			if protocol == "http" {
+				protocol = protocolFromName(name)
+			}
+			return int32(port), protocol
+		}
+	}
+
+	if port, ok := target.(int64); ok {
+		return int32(port), protocol
+	}
+
+	i.unmapped("the Service target port %q does not match a container port: update expose.containerPort", fmt.Sprintf("%v", target))
+	return 0, protocol
+}
+
+// importPodTemplate maps the first container in the pod template and returns it
+func (i *importer) importPodTemplate(podTemplate map[string]interface{}) (map[string]interface{}, error) {
+	i.reportUnmappedFields("pod spec", podTemplate["spec"], "containers")
+
+	containers, _, _ := unstructured.NestedSlice(podTemplate, "spec", "containers")
+	if len(containers) == 0 {
+		return nil, errors.New("the pod template does not contain any containers")
+	}
+	for idx, container := range containers[1:] {
+		containerMap, ok := container.(map[string]interface{})
+		if !ok {
+			i.unmapped("container %d was not imported: invalid container", idx+1)
+			continue
+		}
+		name, _, _ := unstructured.NestedString(containerMap, "name")
+		i.unmapped("container %q was not imported: riser apps have a single container", name)
+	}
+
+	container, ok := containers[0].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid container in the pod template")
+	}
+	containerName, _, _ := unstructured.NestedString(container, "name")
+	i.reportUnmappedFields(fmt.Sprintf("container %q", containerName), container,
+		"name", "image", "ports", "env", "resources", "readinessProbe", "livenessProbe")
+
+	image, _, _ := unstructured.NestedString(container, "image")
+	i.result.AppConfig.Image = i.imageWithoutTag(image)
+	i.importEnv(container)
+	i.importResources(container)
+	i.importProbes(container)
+
+	return container, nil
+}
+
+func (i *importer) imageWithoutTag(image string) string {
+	withoutTag := image
+	reference := ""
+	if idx := strings.Index(image, "@"); idx >= 0 {
+		withoutTag, reference = image[:idx], image[idx:]
+	} else if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		withoutTag, reference = image[:idx], image[idx:]
+	}
+
+	if reference != "" {
+		i.unmapped("image %q: the tag is specified when deploying with \"riser deploy\"", reference[1:])
+	}
+	return withoutTag
+}
+
+func (i *importer) importEnv(container map[string]interface{}) {
+	env, _, _ := unstructured.NestedSlice(container, "env")
+	for _, envVar := range env {
+		envMap, _ := envVar.(map[string]interface{})
+		name, _, _ := unstructured.NestedString(envMap, "name")
+		if _, ok := envMap["valueFrom"]; ok {
+			i.unmapped("env %q: valueFrom is not supported. Use \"riser secrets save\" for secrets", name)
+			continue
+		}
+		if !envVarKeyPattern.MatchString(name) || strings.HasPrefix(name, "RISER_") {
+			i.unmapped("env %q: riser requires uppercase names that do not start with RISER_", name)
+			continue
+		}
+
+		value, _, _ := unstructured.NestedString(envMap, "value")
+		if i.result.AppConfig.Environment == nil {
+			i.result.AppConfig.Environment = map[string]intstr.IntOrString{}
+		}
+		i.result.AppConfig.Environment[name] = intstr.FromString(value)
+	}
+
+	if _, ok := container["envFrom"]; ok {
+		i.unmapped("envFrom is not supported: add each environment variable to env or use \"riser secrets save\"")
+	}
+}
+
+// importResources uses limits since riser apps have a single value for each resource. Requests are used if there are no limits.
+func (i *importer) importResources(container map[string]interface{}) {
+	limits, _, _ := unstructured.NestedStringMap(container, "resources", "limits")
+	requests, _, _ := unstructured.NestedStringMap(container, "resources", "requests")
+	values := limits
+	if len(values) == 0 {
+		values = requests
+	} else if len(requests) > 0 {
+		i.unmapped("resources.requests were not imported: riser uses resources.limits")
+	}
+
+	resources := &model.AppConfigResources{}
+	for _, name := range sortedKeys(values) {
+		value := values[name]
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			i.unmapped("resource %q: invalid quantity %q", name, value)
+			continue
+		}
+
+		switch name {
+		case "cpu":
+			cpuCores := float32(quantity.MilliValue()) / 1000
+			resources.CpuCores = &cpuCores
+		case "memory":
+			memoryMB := int32(quantity.Value() / (1024 * 1024))
+			resources.MemoryMB = &memoryMB
+		default:
+			i.unmapped("resource %q is not supported", name)
+		}
+	}
+
+	if resources.CpuCores != nil || resources.MemoryMB != nil {
+		i.result.AppConfig.Resources = resources
+	}
+}
+
+// importProbes maps the path of an httpGet probe to the health check. The readiness probe takes precedence.
+func (i *importer) importProbes(container map[string]interface{}) {
+	probeName := "readinessProbe"
+	probe, ok, _ := unstructured.NestedMap(container, probeName)
+	if !ok {
+		probeName = "livenessProbe"
+		probe, ok, _ = unstructured.NestedMap(container, probeName)
+	} else if _, hasLiveness := container["livenessProbe"]; hasLiveness {
+		i.unmapped("livenessProbe was not imported: riser uses a single health check")
+	}
+	if !ok {
+		return
+	}
+
+	path, ok, _ := unstructured.NestedString(probe, "httpGet", "path")
+	if !ok {
+		i.unmapped("%s: only httpGet probes are supported", probeName)
+		return
+	}
+
+	i.result.AppConfig.HealthCheck = &model.AppConfigHealthCheck{Path: path}
+	i.reportUnmappedFields(probeName, probe, "httpGet")
+	i.reportUnmappedFields(probeName+".httpGet", probe["httpGet"], "path", "port")
+}
+
+func (i *importer) parseScale(key, value string) *int {
+	scale, err := strconv.Atoi(value)
+	if err != nil {
+		i.unmapped("annotation %q: invalid scale %q", key, value)
+		return nil
+	}
+	return &scale
+}
+
+// reportUnmappedObjects reports every object that was not imported
+func (i *importer) reportUnmappedObjects(objects []*unstructured.Unstructured, imported ...*unstructured.Unstructured) {
+	for _, object := range objects {
+		if !containsObject(imported, object) {
+			i.unmapped("%s %q was not imported", object.GetKind(), object.GetName())
+		}
+	}
+}
+
+// reportUnmappedFields reports each field of an object that is not in the list of mapped fields
+func (i *importer) reportUnmappedFields(description string, object interface{}, mappedFields ...string) {
+	objectMap, _ := object.(map[string]interface{})
+	unmappedFields := []string{}
+	for field := range objectMap {
+		if !containsString(mappedFields, field) {
+			unmappedFields = append(unmappedFields, field)
+		}
+	}
+	sort.Strings(unmappedFields)
+	for _, field := range unmappedFields {
+		i.unmapped("%s: %s was not imported", description, field)
+	}
+}
+
+func (i *importer) unmapped(format string, args ...interface{}) {
+	i.result.Unmapped = append(i.result.Unmapped, fmt.Sprintf(format, args...))
+}
+
+func isDeployment(object *unstructured.Unstructured) bool {
+	return object.GetKind() == "Deployment"
+}
+
+func isKnativeService(object *unstructured.Unstructured) bool {
+	return object.GetKind() == "Service" && object.GroupVersionKind().Group == knativeServingGroup
+}
+
+// protocolFromName infers the protocol from a port name or app protocol (e.g. "h2c" or "grpc-web")
+func protocolFromName(name string) string {
+	name = strings.ToLower(name)
+	if name == "h2c" || strings.HasPrefix(name, "http2") || strings.HasPrefix(name, "grpc") {
+		return "http2"
+	}
+	return "http"
+}
+
+func selectorMatches(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// ingressServiceNames returns the names of all services that an Ingress (networking.k8s.io/v1 or v1beta1) routes to
+func (i *importer) ingressServiceNames(ingress *unstructured.Unstructured) []string {
+	names := []string{}
+	backends := []map[string]interface{}{}
+	if backend, ok, _ := unstructured.NestedMap(ingress.Object, "spec", "defaultBackend"); ok {
+		backends = append(backends, backend)
+	}
+	if backend, ok, _ := unstructured.NestedMap(ingress.Object, "spec", "backend"); ok {
+		backends = append(backends, backend)
+	}
+	rules, _, _ := unstructured.NestedSlice(ingress.Object, "spec", "rules")
+	for ruleIdx, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok {
+			i.unmapped("Ingress %q: invalid rule %d", ingress.GetName(), ruleIdx)
+			continue
+		}
+		paths, _, _ := unstructured.NestedSlice(ruleMap, "http", "paths")
+		for pathIdx, path := range paths {
+			pathMap, ok := path.(map[string]interface{})
+			if !ok {
+				i.unmapped("Ingress %q: invalid path %d in rule %d", ingress.GetName(), pathIdx, ruleIdx)
+				continue
+			}
+			if backend, ok, _ := unstructured.NestedMap(pathMap, "backend"); ok {
+				backends = append(backends, backend)
+			}
+		}
+	}
+
+	for _, backend := range backends {
+		if name, ok, _ := unstructured.NestedString(backend, "service", "name"); ok {
+			names = append(names, name)
+		}
+		if name, ok, _ := unstructured.NestedString(backend, "serviceName"); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsObject(objects []*unstructured.Unstructured, object *unstructured.Unstructured) bool {
+	for _, o := range objects {
+		if o == object {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,295 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const deploymentManifest = `
+# Source: mychart/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: myapp
+  namespace: prod
+spec:
+  replicas: 3
+  strategy:
+    type: RollingUpdate
+  selector:
+    matchLabels:
+      app: myapp
+  template:
+    metadata:
+      labels:
+        app: myapp
+    spec:
+      serviceAccountName: myapp
+      containers:
+      - name: myapp
+        image: registry.example.com:5000/team/myapp:v1.2.3
+        command: ["/app"]
+        ports:
+        - name: metrics
+          containerPort: 9090
+        - name: web
+          containerPort: 8080
+        env:
+        - name: LOG_LEVEL
+          value: debug
+        - name: DB_PASSWORD
+          valueFrom:
+            secretKeyRef:
+              name: db
+              key: password
+        resources:
+          requests:
+            cpu: 100m
+          limits:
+            cpu: 500m
+            memory: 256Mi
+        readinessProbe:
+          httpGet:
+            path: /ready
+            port: web
+          periodSeconds: 5
+        livenessProbe:
+          httpGet:
+            path: /health
+            port: web
+      - name: sidecar
+        image: envoy
+---
+# Source: mychart/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: myapp
+spec:
+  selector:
+    app: myapp
+  ports:
+  - port: 80
+    targetPort: web
+---
+# Source: mychart/templates/hpa.yaml
+apiVersion: autoscaling/v2beta2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: myapp
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: myapp
+  minReplicas: 2
+  maxReplicas: 10
+  metrics:
+  - type: Resource
+---
+# Source: mychart/templates/ingress.yaml
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: myapp
+spec:
+  rules:
+  - host: myapp.example.com
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: myapp
+            port:
+              number: 80
+---
+# Source: mychart/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: myconfig
+---
+`
+
+const knativeManifest = `
+apiVersion: serving.knative.dev/v1
+kind: Service
+metadata:
+  name: myksvc
+  labels:
+    networking.knative.dev/visibility: cluster-local
+spec:
+  template:
+    metadata:
+      annotations:
+        autoscaling.knative.dev/minScale: "1"
+        autoscaling.knative.dev/maxScale: "5"
+        autoscaling.knative.dev/target: "10"
+    spec:
+      containers:
+      - image: ghcr.io/me/myksvc@sha256:abc123
+        ports:
+        - name: h2c
+          containerPort: 50051
+`
+
+func Test_FromManifest_Deployment(t *testing.T) {
+	result, err := FromManifest(strings.NewReader(deploymentManifest))
+
+	require.NoError(t, err)
+	appConfig := result.AppConfig
+	assert.EqualValues(t, "myapp", appConfig.Name)
+	assert.Equal(t, "registry.example.com:5000/team/myapp", appConfig.Image)
+	assert.Equal(t, &model.AppConfigExpose{ContainerPort: 8080, Protocol: "http", Scope: model.AppExposeScope_External}, appConfig.Expose)
+	assert.Equal(t, map[string]intstr.IntOrString{"LOG_LEVEL": intstr.FromString("debug")}, appConfig.Environment)
+	assert.InDelta(t, 0.5, *appConfig.Resources.CpuCores, 0.001)
+	assert.EqualValues(t, 256, *appConfig.Resources.MemoryMB)
+	assert.Equal(t, "/ready", appConfig.HealthCheck.Path)
+	// The HPA takes precedence over replicas
+	assert.Equal(t, 2, *appConfig.Autoscale.Min)
+	assert.Equal(t, 10, *appConfig.Autoscale.Max)
+
+	assert.Equal(t, []string{
+		`Deployment "myapp" spec: strategy was not imported`,
+		`pod spec: serviceAccountName was not imported`,
+		`container "sidecar" was not imported: riser apps have a single container`,
+		`container "myapp": command was not imported`,
+		`image "v1.2.3": the tag is specified when deploying with "riser deploy"`,
+		`env "DB_PASSWORD": valueFrom is not supported. Use "riser secrets save" for secrets`,
+		`resources.requests were not imported: riser uses resources.limits`,
+		`livenessProbe was not imported: riser uses a single health check`,
+		`readinessProbe: periodSeconds was not imported`,
+		`Ingress "myapp" was not imported: external apps are exposed by the riser gateway`,
+		`ConfigMap "myconfig" was not imported`,
+		`HorizontalPodAutoscaler "myapp" spec: metrics was not imported`,
+	}, result.Unmapped)
+}
+
+func Test_FromManifest_Deployment_Minimal(t *testing.T) {
+	manifest := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: worker
+        image: worker
+        ports:
+        - containerPort: 3000
+`
+	result, err := FromManifest(strings.NewReader(manifest))
+
+	require.NoError(t, err)
+	assert.Equal(t, "worker", result.AppConfig.Image)
+	assert.Equal(t, &model.AppConfigExpose{ContainerPort: 3000, Protocol: "http", Scope: model.AppExposeScope_Cluster}, result.AppConfig.Expose)
+	assert.Equal(t, 2, *result.AppConfig.Autoscale.Min)
+	assert.Equal(t, 2, *result.AppConfig.Autoscale.Max)
+	assert.Nil(t, result.AppConfig.HealthCheck)
+	assert.Equal(t, []string{`no Service selects the Deployment "worker": the app will only be reachable within the cluster`}, result.Unmapped)
+}
+
+func Test_FromManifest_Malformed(t *testing.T) {
+	manifest := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+spec:
+  template:
+    spec:
+      containers:
+      - name: worker
+        image: worker
+        ports:
+        - containerPort: 3000
+      - sidecar
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: worker
+spec:
+  rules:
+  - bad
+  - http:
+      paths:
+      - bad
+`
+	result, err := FromManifest(strings.NewReader(manifest))
+
+	require.NoError(t, err)
+	assert.Equal(t, "worker", result.AppConfig.Image)
+	assert.Contains(t, result.Unmapped, "container 1 was not imported: invalid container")
+	assert.Contains(t, result.Unmapped, `Ingress "worker": invalid rule 0`)
+	assert.Contains(t, result.Unmapped, `Ingress "worker": invalid path 0 in rule 1`)
+}
+
+func Test_FromManifest_KnativeService(t *testing.T) {
+	result, err := FromManifest(strings.NewReader(knativeManifest))
+
+	require.NoError(t, err)
+	appConfig := result.AppConfig
+	assert.EqualValues(t, "myksvc", appConfig.Name)
+	assert.Equal(t, "ghcr.io/me/myksvc", appConfig.Image)
+	assert.Equal(t, &model.AppConfigExpose{ContainerPort: 50051, Protocol: "http2", Scope: model.AppExposeScope_Cluster}, appConfig.Expose)
+	assert.Equal(t, 1, *appConfig.Autoscale.Min)
+	assert.Equal(t, 5, *appConfig.Autoscale.Max)
+	assert.Equal(t, []string{
+		`image "sha256:abc123": the tag is specified when deploying with "riser deploy"`,
+		`annotation "autoscaling.knative.dev/target": riser only supports min and max scale`,
+	}, result.Unmapped)
+}
+
+func Test_FromManifest_List(t *testing.T) {
+	manifest := `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: myapp
+  spec:
+    template:
+      spec:
+        containers:
+        - name: myapp
+          image: myapp
+`
+	result, err := FromManifest(strings.NewReader(manifest))
+
+	require.NoError(t, err)
+	assert.EqualValues(t, "myapp", result.AppConfig.Name)
+	assert.Contains(t, result.Unmapped, "no container port found: update expose.containerPort")
+}
+
+func Test_FromManifest_Errors(t *testing.T) {
+	tests := []struct {
+		manifest string
+		err      string
+	}{
+		{"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: foo\n", "the manifest must contain exactly one Deployment or Knative Service (found 0)"},
+		{knativeManifest + "---\n" + knativeManifest, "the manifest must contain exactly one Deployment or Knative Service (found 2)"},
+		{"apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: foo\n", "the pod template does not contain any containers"},
+		{"foo: [", "Error parsing manifest"},
+	}
+
+	for _, tt := range tests {
+		result, err := FromManifest(strings.NewReader(tt.manifest))
+
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), tt.err)
+	}
+}
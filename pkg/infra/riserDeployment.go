@@ -149,8 +149,10 @@ func (deployment *RiserDeployment) Deploy() error {
 					ServerURL: "https://riser-server.riser-system.demo.riser",
 					Apikey:    apiKey,
 					Secure:    &secure}
-				deployment.RiserConfig.SetContext(newRiserContext)
-				return rc.SaveRc(deployment.RiserConfig)
+				return rc.UpdateRc(deployment.RiserConfig, func(latest *rc.RuntimeConfiguration) error {
+					latest.SetContext(newRiserContext)
+					return nil
+				})
 			}),
 		steps.NewShellExecStep("Wait for riser-server", "kubectl wait --for=condition=ready --timeout=120s ksvc/riser-server -n riser-system"),
 		// This allows for faster e2e runs as the crash backoff is too slow. Init containers could be used here too.
@@ -0,0 +1,22 @@
+package logger
+
+import "os"
+
+// diagnosticLogger is the global shared instance of Logger for messages about the CLI itself
+var diagnosticLogger = Logger(&ScreenLogger{Out: os.Stderr})
+
+// Diagnostic returns the logger for messages about the CLI itself (e.g. HTTP traces) rather than about the command.
+// Screen messages are written to stderr so that they do not interfere with command output (e.g. "-o json").
+func Diagnostic() Logger {
+	return diagnosticLogger
+}
+
+// DiagnosticWithFields returns the diagnostic logger with fields attached
+func DiagnosticWithFields(fields Fields) Logger {
+	return withFields(diagnosticLogger, fields)
+}
+
+// SetDiagnosticLogger sets the shared diagnostic logger. This should only be done on process startup. This is not thread safe.
+func SetDiagnosticLogger(l Logger) {
+	diagnosticLogger = l
+}
@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+)
+
+const (
+	levelVerbose = "verbose"
+	levelInfo    = "info"
+	levelWarn    = "warn"
+	levelError   = "error"
+)
+
+// ansiEscapePattern matches color codes. Messages are often styled for humans before they are logged.
+var ansiEscapePattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// JSONLogger writes one JSON object per line for consumption by machines (e.g. CI systems)
+type JSONLogger struct {
+	VerboseMode bool
+	writer      io.Writer
+	command     string
+	fields      Fields
+	// mutex is shared between loggers created with WithFields so that lines are not interleaved
+	mutex *sync.Mutex
+	now   func() time.Time
+}
+
+// NewJSONLogger creates a logger that writes JSON lines to the writer. The command is included in each line.
+func NewJSONLogger(writer io.Writer, command string, verbose bool) *JSONLogger {
+	return &JSONLogger{
+		VerboseMode: verbose,
+		writer:      writer,
+		command:     command,
+		fields:      Fields{},
+		mutex:       &sync.Mutex{},
+		now:         time.Now,
+	}
+}
+
+// WithFields returns a logger that includes the fields in each line
+func (logger *JSONLogger) WithFields(fields Fields) Logger {
+	merged := Fields{}
+	for key, value := range logger.fields {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+
+	child := *logger
+	child.fields = merged
+	return &child
+}
+
+// Verbose logs a verbose message
+func (logger *JSONLogger) Verbose(message string) {
+	if logger.VerboseMode {
+		logger.log(levelVerbose, message)
+	}
+}
+
+// Info logs an information message
+func (logger *JSONLogger) Info(message string) {
+	logger.log(levelInfo, message)
+}
+
+// Warn logs a warning message
+func (logger *JSONLogger) Warn(message string) {
+	logger.log(levelWarn, message)
+}
+
+// Error logs an error message
+func (logger *JSONLogger) Error(message string) {
+	logger.log(levelError, message)
+}
+
+func (logger *JSONLogger) log(level string, message string) {
+	entry := map[string]interface{}{}
+	for key, value := range logger.fields {
+		entry[key] = value
+	}
+	// Reserved keys take precedence over fields
+	entry["level"] = level
+	entry["time"] = logger.now().UTC().Format(time.RFC3339Nano)
+	entry["command"] = logger.command
+	entry["message"] = ansiEscapePattern.ReplaceAllString(message, "")
+
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		// Fields should always be serializable so this should never happen
+		entryBytes, _ = json.Marshal(map[string]interface{}{"level": levelError, "message": err.Error()})
+	}
+
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	_, _ = logger.writer.Write(append(entryBytes, '\n'))
+}
@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wzshiming/ctc"
+)
+
+func newTestJSONLogger(b *bytes.Buffer, verbose bool) *JSONLogger {
+	logger := NewJSONLogger(b, "riser deploy", verbose)
+	logger.now = func() time.Time { return time.Date(2020, 9, 1, 12, 30, 0, 0, time.UTC) }
+	return logger
+}
+
+func Test_JSONLogger(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := newTestJSONLogger(b, false)
+
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+	logger.Verbose("verbose")
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.JSONEq(t, `{"level":"info","time":"2020-09-01T12:30:00Z","command":"riser deploy","message":"info"}`, lines[0])
+	assert.JSONEq(t, `{"level":"warn","time":"2020-09-01T12:30:00Z","command":"riser deploy","message":"warn"}`, lines[1])
+	assert.JSONEq(t, `{"level":"error","time":"2020-09-01T12:30:00Z","command":"riser deploy","message":"error"}`, lines[2])
+}
+
+func Test_JSONLogger_Verbose(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := newTestJSONLogger(b, true)
+
+	logger.Verbose("verbose")
+
+	assert.JSONEq(t, `{"level":"verbose","time":"2020-09-01T12:30:00Z","command":"riser deploy","message":"verbose"}`, b.String())
+}
+
+func Test_JSONLogger_WithFields(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := newTestJSONLogger(b, false)
+
+	logger.WithFields(Fields{"step": "step1", "level": "ignored"}).(FieldLogger).WithFields(Fields{"attempt": 2}).Info("info")
+	logger.Info("no fields")
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	assert.JSONEq(t, `{"level":"info","time":"2020-09-01T12:30:00Z","command":"riser deploy","message":"info","step":"step1","attempt":2}`, lines[0])
+	assert.JSONEq(t, `{"level":"info","time":"2020-09-01T12:30:00Z","command":"riser deploy","message":"no fields"}`, lines[1])
+}
+
+func Test_JSONLogger_StripsColor(t *testing.T) {
+	b := &bytes.Buffer{}
+	logger := newTestJSONLogger(b, false)
+
+	logger.Info(ctc.ForegroundBrightGreen.String() + "✔" + ctc.Reset.String() + " Complete")
+
+	assert.JSONEq(t, `{"level":"info","time":"2020-09-01T12:30:00Z","command":"riser deploy","message":"✔ Complete"}`, b.String())
+}
+
+func Test_MultiLogger_WithFields(t *testing.T) {
+	b := &bytes.Buffer{}
+	fake := NewFakeLogger()
+	logger := MultiLogger{fake, newTestJSONLogger(b, false)}
+
+	logger.WithFields(Fields{"step": "step1"}).Info("info")
+
+	assert.Equal(t, []string{"info"}, fake.InfoLogs)
+	assert.JSONEq(t, `{"level":"info","time":"2020-09-01T12:30:00Z","command":"riser deploy","message":"info","step":"step1"}`, b.String())
+}
@@ -9,6 +9,16 @@ type Logger interface {
 	Verbose(string)
 }
 
+// Fields are structured key/value pairs that are attached to a log entry
+type Fields map[string]interface{}
+
+// FieldLogger is a Logger that supports structured fields
+type FieldLogger interface {
+	Logger
+	// WithFields returns a logger that attaches the fields to each log entry in addition to any existing fields
+	WithFields(fields Fields) Logger
+}
+
 // logger is the global shared instance of Logger
 var logger = Logger(NewScreenLogger(false))
 
@@ -17,6 +27,19 @@ func Log() Logger {
 	return logger
 }
 
+// WithFields returns the default logger with fields attached. Fields are ignored if the default logger does not support them
+// (e.g. the ScreenLogger, since fields are intended for machines rather than humans).
+func WithFields(fields Fields) Logger {
+	return withFields(logger, fields)
+}
+
+func withFields(l Logger, fields Fields) Logger {
+	if fieldLogger, ok := l.(FieldLogger); ok {
+		return fieldLogger.WithFields(fields)
+	}
+	return l
+}
+
 // SetLogger sets the shared logger. This should only be done on process startup. This is not thread safe.
 func SetLogger(l Logger) {
 	logger = l
@@ -0,0 +1,41 @@
+package logger
+
+// MultiLogger logs to multiple loggers (e.g. to the screen and to a log file)
+type MultiLogger []Logger
+
+// WithFields attaches fields to each logger that supports them
+func (loggers MultiLogger) WithFields(fields Fields) Logger {
+	withFieldLoggers := MultiLogger{}
+	for _, l := range loggers {
+		withFieldLoggers = append(withFieldLoggers, withFields(l, fields))
+	}
+	return withFieldLoggers
+}
+
+// Verbose logs a verbose message
+func (loggers MultiLogger) Verbose(message string) {
+	for _, l := range loggers {
+		l.Verbose(message)
+	}
+}
+
+// Info logs an information message
+func (loggers MultiLogger) Info(message string) {
+	for _, l := range loggers {
+		l.Info(message)
+	}
+}
+
+// Warn logs a warning message
+func (loggers MultiLogger) Warn(message string) {
+	for _, l := range loggers {
+		l.Warn(message)
+	}
+}
+
+// Error logs an error message
+func (loggers MultiLogger) Error(message string) {
+	for _, l := range loggers {
+		l.Error(message)
+	}
+}
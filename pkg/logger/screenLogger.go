@@ -2,6 +2,7 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"riser/pkg/ui/style"
 )
@@ -9,6 +10,8 @@ import (
 // ScreenLogger logs to a terminal
 type ScreenLogger struct {
 	VerboseMode bool
+	// Out is where verbose, info, and warning messages are written (default: stdout). Errors are always written to stderr.
+	Out io.Writer
 }
 
 // NewScreenLogger creates a logger instance designed for printing messages to the screen for humans
@@ -19,21 +22,28 @@ func NewScreenLogger(verbose bool) *ScreenLogger {
 // Verbose logs a verbose message
 func (logger *ScreenLogger) Verbose(message string) {
 	if logger.VerboseMode {
-		fmt.Println(style.Muted(message))
+		fmt.Fprintln(logger.out(), style.Muted(message))
 	}
 }
 
 // Info logs an information message to the screen
 func (logger *ScreenLogger) Info(message string) {
-	fmt.Println(message)
+	fmt.Fprintln(logger.out(), message)
 }
 
 // Warn logs a warning message to the screen
 func (logger *ScreenLogger) Warn(message string) {
-	fmt.Println(style.Warn(message))
+	fmt.Fprintln(logger.out(), style.Warn(message))
 }
 
 // Error logs an error message to the screen
 func (logger *ScreenLogger) Error(message string) {
 	fmt.Fprintf(os.Stderr, "%s\n", style.Bad(message))
 }
+
+func (logger *ScreenLogger) out() io.Writer {
+	if logger.Out == nil {
+		return os.Stdout
+	}
+	return logger.Out
+}
@@ -0,0 +1,108 @@
+package policy
+
+import (
+	"fmt"
+	"path"
+	"time"
+)
+
+// FreezeWindow is a period of time during which changes to environments are not allowed
+type FreezeWindow struct {
+	Name string `json:"name" yaml:"name"`
+	// Environments are the names of the environments that are frozen. Supports glob patterns (e.g. "prod-*"). When empty all
+	// environments are frozen.
+	Environments []string `json:"environments,omitempty" yaml:"environments,omitempty"`
+	// Start is the start of the freeze in RFC3339 format (e.g. "2020-12-24T00:00:00Z")
+	Start string `json:"start" yaml:"start"`
+	// End is the end of the freeze in RFC3339 format
+	End string `json:"end" yaml:"end"`
+}
+
+// Validate returns an error if the start or end are not in RFC3339 format or if the end is not after the start
+func (window *FreezeWindow) Validate() error {
+	start, end, err := window.parse()
+	if err != nil {
+		return err
+	}
+	if !end.After(start) {
+		return fmt.Errorf("freeze window %q: end must be after start", window.Name)
+	}
+	if err = validatePatterns(window.Environments); err != nil {
+		return fmt.Errorf("freeze window %q: %v", window.Name, err)
+	}
+	return nil
+}
+
+// IsActive returns true if the environment is frozen at the specified time. An invalid window (e.g. a typo in the start
+// or end) is always active for its environments so that a mistake does not silently disable the freeze.
+func (window *FreezeWindow) IsActive(environmentName string, now time.Time) bool {
+	if !window.appliesTo(environmentName) {
+		return false
+	}
+	start, end, err := window.parse()
+	if err != nil || !end.After(start) {
+		return true
+	}
+	return !now.Before(start) && now.Before(end)
+}
+
+func (window *FreezeWindow) appliesTo(environmentName string) bool {
+	if len(window.Environments) == 0 {
+		return true
+	}
+	for _, pattern := range window.Environments {
+		// An invalid pattern applies to all environments for the same reason as an invalid time
+		if matched, err := path.Match(pattern, environmentName); matched || err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// EndTime returns the end of the freeze window
+func (window *FreezeWindow) EndTime() time.Time {
+	_, end, _ := window.parse()
+	return end
+}
+
+func (window *FreezeWindow) parse() (time.Time, time.Time, error) {
+	start, err := time.Parse(time.RFC3339, window.Start)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("freeze window %q: start must be in RFC3339 format (e.g. \"2020-12-24T00:00:00Z\")", window.Name)
+	}
+	end, err := time.Parse(time.RFC3339, window.End)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("freeze window %q: end must be in RFC3339 format (e.g. \"2020-12-24T00:00:00Z\")", window.Name)
+	}
+	return start, end, nil
+}
+
+// ActiveFreezeWindow returns the first freeze window that is active for the environment or nil if the environment is not frozen
+func ActiveFreezeWindow(windows []FreezeWindow, environmentName string, now time.Time) *FreezeWindow {
+	for idx := range windows {
+		if windows[idx].IsActive(environmentName, now) {
+			return &windows[idx]
+		}
+	}
+	return nil
+}
+
+// IsProtectedEnvironment returns true if the environment name matches any of the policy's protected environment patterns
+func (policy *Policy) IsProtectedEnvironment(environmentName string) bool {
+	for _, pattern := range policy.ProtectedEnvironments {
+		if matched, _ := path.Match(pattern, environmentName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePatterns returns an error if any of the glob patterns are invalid
+func validatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q", pattern)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FreezeWindow_IsActive(t *testing.T) {
+	window := &FreezeWindow{Name: "holidays", Environments: []string{"prod", "prod-*"}, Start: "2020-12-24T00:00:00Z", End: "2021-01-04T00:00:00Z"}
+	during := time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		environmentName string
+		now             time.Time
+		expected        bool
+	}{
+		{"prod", during, true},
+		{"prod-eu", during, true},
+		{"dev", during, false},
+		{"prod", time.Date(2020, 12, 23, 23, 59, 59, 0, time.UTC), false},
+		{"prod", time.Date(2020, 12, 24, 0, 0, 0, 0, time.UTC), true},
+		{"prod", time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, window.IsActive(tt.environmentName, tt.now), "%s %s", tt.environmentName, tt.now)
+	}
+
+	// No environments freezes all environments
+	assert.True(t, (&FreezeWindow{Start: window.Start, End: window.End}).IsActive("dev", during))
+	// Invalid windows are always active for their environments
+	assert.True(t, (&FreezeWindow{Environments: []string{"prod"}, Start: "2020-12-24", End: window.End}).IsActive("prod", during))
+	assert.True(t, (&FreezeWindow{Environments: []string{"prod"}, Start: window.End, End: window.Start}).IsActive("prod", during))
+	assert.False(t, (&FreezeWindow{Environments: []string{"prod"}, Start: "2020-12-24", End: window.End}).IsActive("dev", during))
+	assert.True(t, (&FreezeWindow{Environments: []string{"[prod"}, Start: window.Start, End: window.End}).IsActive("dev", during))
+}
+
+func Test_FreezeWindow_Validate(t *testing.T) {
+	tests := []struct {
+		window FreezeWindow
+		err    string
+	}{
+		{FreezeWindow{Name: "a", Start: "2020-12-24T00:00:00Z", End: "2020-12-25T00:00:00-05:00"}, ""},
+		{FreezeWindow{Name: "a", Start: "2020-12-24", End: "2020-12-25T00:00:00Z"}, `freeze window "a": start must be in RFC3339 format (e.g. "2020-12-24T00:00:00Z")`},
+		{FreezeWindow{Name: "a", Start: "2020-12-24T00:00:00Z"}, `freeze window "a": end must be in RFC3339 format (e.g. "2020-12-24T00:00:00Z")`},
+		{FreezeWindow{Name: "a", Start: "2020-12-24T00:00:00Z", End: "2020-12-24T00:00:00Z"}, `freeze window "a": end must be after start`},
+		{FreezeWindow{Name: "a", Start: "2020-12-24T00:00:00Z", End: "2020-12-25T00:00:00Z", Environments: []string{"[prod"}}, `freeze window "a": invalid pattern "[prod"`},
+	}
+
+	for _, tt := range tests {
+		err := tt.window.Validate()
+
+		if tt.err == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.Equal(t, tt.err, err.Error())
+		}
+	}
+}
+
+func Test_ActiveFreezeWindow(t *testing.T) {
+	windows := []FreezeWindow{
+		{Name: "a", Environments: []string{"dev"}, Start: "2020-12-24T00:00:00Z", End: "2021-01-04T00:00:00Z"},
+		{Name: "b", Environments: []string{"prod"}, Start: "2020-12-24T00:00:00Z", End: "2021-01-04T00:00:00Z"},
+	}
+	now := time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "b", ActiveFreezeWindow(windows, "prod", now).Name)
+	assert.Nil(t, ActiveFreezeWindow(windows, "test", now))
+}
+
+func Test_Policy_IsProtectedEnvironment(t *testing.T) {
+	policy := &Policy{ProtectedEnvironments: []string{"prod*"}}
+
+	assert.True(t, policy.IsProtectedEnvironment("prod-eu"))
+	assert.False(t, policy.IsProtectedEnvironment("dev"))
+}
@@ -0,0 +1,237 @@
+// Package policy checks an app config against the rules in a policy file before it is deployed
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/riser-platform/riser-server/api/v1/model"
+)
+
+// DefaultPolicyFileNames are the names of the policy file in the same directory as the app config
+var DefaultPolicyFileNames = []string{".riser-policy.yaml", ".riser-policy.yml"}
+
+const (
+	// RuleTypeDisallowedTags fails when the docker tag is one of the rule's tags (default: latest)
+	RuleTypeDisallowedTags = "disallowedTags"
+	// RuleTypeRequireResources fails when the cpu or memory resources are not specified
+	RuleTypeRequireResources = "requireResources"
+	// RuleTypeMaxAutoscale fails when autoscale.max is greater than the rule's max
+	RuleTypeMaxAutoscale = "maxAutoscale"
+	// RuleTypeDisallowedEnvNames fails when an environment variable name matches one of the rule's patterns (default: names that look like secrets)
+	RuleTypeDisallowedEnvNames = "disallowedEnvNames"
+)
+
+var defaultDisallowedTags = []string{"latest"}
+
+var defaultDisallowedEnvNamePatterns = []string{"*PASSWORD*", "*SECRET*", "*TOKEN", "*API_KEY*", "*PRIVATE_KEY*", "*CREDENTIALS*"}
+
+// Policy is a set of rules that an app config must satisfy and the guards for changing environments
+type Policy struct {
+	Rules []Rule `json:"rules"`
+	// ProtectedEnvironments are glob patterns (e.g. "prod*") for environments that require confirmation before changes are applied
+	ProtectedEnvironments []string `json:"protectedEnvironments,omitempty"`
+	// FreezeWindows are periods of time during which deploys, rollouts, and deletes are not allowed
+	FreezeWindows []FreezeWindow `json:"freezeWindows,omitempty"`
+}
+
+// Rule is a single policy rule
+type Rule struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+	// Environments are the names of the environments that the rule applies to. Supports glob patterns (e.g. "prod-*"). When
+	// empty the rule applies to all environments.
+	Environments []string `json:"environments,omitempty"`
+	// Tags are the docker tags that are not allowed (disallowedTags)
+	Tags []string `json:"tags,omitempty"`
+	// Max is the maximum value for autoscale.max (maxAutoscale)
+	Max *int `json:"max,omitempty"`
+	// Patterns are glob patterns for environment variable names that are not allowed (disallowedEnvNames)
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// Violation is a rule that an app config does not satisfy
+type Violation struct {
+	RuleId  string `json:"ruleId"`
+	Message string `json:"message"`
+}
+
+// Load loads the policy file in a directory. Returns nil if the directory does not contain a policy file.
+func Load(dir string) (*Policy, string, error) {
+	for _, fileName := range DefaultPolicyFileNames {
+		policyPath := filepath.Join(dir, fileName)
+		rawFile, err := ioutil.ReadFile(policyPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, policyPath, err
+		}
+
+		policy := &Policy{}
+		err = yaml.UnmarshalStrict(rawFile, policy, yaml.DisallowUnknownFields)
+		if err != nil {
+			return nil, policyPath, errors.Wrapf(err, "error parsing policy file %q", policyPath)
+		}
+		err = policy.Validate()
+		if err != nil {
+			return nil, policyPath, errors.Wrapf(err, "invalid policy file %q", policyPath)
+		}
+		return policy, policyPath, nil
+	}
+
+	return nil, "", nil
+}
+
+// Validate returns an error if a rule is missing an id, has a duplicate id, or has an unknown type or invalid parameters,
+// or if a protected environment pattern or freeze window is invalid
+func (policy *Policy) Validate() error {
+	ids := map[string]bool{}
+	for idx, rule := range policy.Rules {
+		if rule.Id == "" {
+			return fmt.Errorf("rule %d must have an id", idx)
+		}
+		if ids[rule.Id] {
+			return fmt.Errorf("rule %q: the id must be unique", rule.Id)
+		}
+		ids[rule.Id] = true
+
+		if err := validatePatterns(append(append([]string{}, rule.Environments...), rule.Patterns...)); err != nil {
+			return fmt.Errorf("rule %q: %v", rule.Id, err)
+		}
+
+		switch rule.Type {
+		case RuleTypeDisallowedTags, RuleTypeRequireResources, RuleTypeDisallowedEnvNames:
+		case RuleTypeMaxAutoscale:
+			if rule.Max == nil {
+				return fmt.Errorf("rule %q: max is required", rule.Id)
+			}
+		default:
+			return fmt.Errorf("rule %q: unknown type %q. Must be one of: %s", rule.Id, rule.Type,
+				strings.Join([]string{RuleTypeDisallowedEnvNames, RuleTypeDisallowedTags, RuleTypeMaxAutoscale, RuleTypeRequireResources}, ", "))
+		}
+	}
+
+	if err := validatePatterns(policy.ProtectedEnvironments); err != nil {
+		return fmt.Errorf("protectedEnvironments: %v", err)
+	}
+	for idx := range policy.FreezeWindows {
+		if err := policy.FreezeWindows[idx].Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HasRule returns true if the policy contains a rule with the id
+func (policy *Policy) HasRule(id string) bool {
+	for _, rule := range policy.Rules {
+		if rule.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns the rules that the app does not satisfy in an environment. Rules with an id in skipRuleIds are not checked.
+// When environmentName is empty only rules that apply to all environments are checked. When dockerTag is empty tag rules
+// are not checked.
+func (policy *Policy) Check(app *model.AppConfigWithOverrides, environmentName, dockerTag string, skipRuleIds []string) ([]Violation, error) {
+	appConfig := &app.AppConfig
+	if environmentName != "" {
+		var err error
+		appConfig, err = app.ApplyOverrides(environmentName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	violations := []Violation{}
+	for _, rule := range policy.Rules {
+		if containsString(skipRuleIds, rule.Id) || !rule.appliesTo(environmentName) {
+			continue
+		}
+		for _, message := range rule.check(appConfig, dockerTag) {
+			violations = append(violations, Violation{RuleId: rule.Id, Message: message})
+		}
+	}
+
+	return violations, nil
+}
+
+func (rule *Rule) appliesTo(environmentName string) bool {
+	if len(rule.Environments) == 0 {
+		return true
+	}
+	for _, pattern := range rule.Environments {
+		if matched, _ := path.Match(pattern, environmentName); matched && environmentName != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *Rule) check(appConfig *model.AppConfig, dockerTag string) []string {
+	messages := []string{}
+	switch rule.Type {
+	case RuleTypeDisallowedTags:
+		tags := rule.Tags
+		if len(tags) == 0 {
+			tags = defaultDisallowedTags
+		}
+		if dockerTag != "" && containsString(tags, dockerTag) {
+			messages = append(messages, fmt.Sprintf("the docker tag %q is not allowed", dockerTag))
+		}
+	case RuleTypeRequireResources:
+		resources := appConfig.Resources
+		if resources == nil {
+			resources = &model.AppConfigResources{}
+		}
+		if resources.CpuCores == nil {
+			messages = append(messages, "resources.cpuCores is required")
+		}
+		if resources.MemoryMB == nil {
+			messages = append(messages, "resources.memoryMB is required")
+		}
+	case RuleTypeMaxAutoscale:
+		if appConfig.Autoscale != nil && appConfig.Autoscale.Max != nil && *appConfig.Autoscale.Max > *rule.Max {
+			messages = append(messages, fmt.Sprintf("autoscale.max (%d) must be less than or equal to %d", *appConfig.Autoscale.Max, *rule.Max))
+		}
+	case RuleTypeDisallowedEnvNames:
+		patterns := rule.Patterns
+		if len(patterns) == 0 {
+			patterns = defaultDisallowedEnvNamePatterns
+		}
+		names := []string{}
+		for name := range appConfig.Environment {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, pattern := range patterns {
+				if matched, _ := path.Match(strings.ToUpper(pattern), strings.ToUpper(name)); matched {
+					messages = append(messages, fmt.Sprintf("the environment variable %q looks like a secret. Use \"riser secrets save\" instead", name))
+					break
+				}
+			}
+		}
+	}
+	return messages
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package policy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"riser/pkg/util"
+	"testing"
+
+	"github.com/riser-platform/riser-server/api/v1/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_Load(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "riser-policy")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	result, policyPath, err := Load(tmpDir)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Empty(t, policyPath)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(tmpDir, ".riser-policy.yml"), []byte(`
+rules:
+  - id: no-latest
+    type: disallowedTags
+    environments: [prod]
+  - id: max-autoscale
+    type: maxAutoscale
+    max: 10
+`), 0644))
+
+	result, policyPath, err = Load(tmpDir)
+
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(tmpDir, ".riser-policy.yml"), policyPath)
+	require.Len(t, result.Rules, 2)
+	assert.Equal(t, Rule{Id: "no-latest", Type: RuleTypeDisallowedTags, Environments: []string{"prod"}}, result.Rules[0])
+	assert.Equal(t, 10, *result.Rules[1].Max)
+}
+
+func Test_Validate(t *testing.T) {
+	tests := []struct {
+		rules []Rule
+		err   string
+	}{
+		{[]Rule{{Id: "a", Type: RuleTypeRequireResources}}, ""},
+		{[]Rule{{Type: RuleTypeRequireResources}}, "rule 0 must have an id"},
+		{[]Rule{{Id: "a", Type: RuleTypeRequireResources}, {Id: "a", Type: RuleTypeDisallowedTags}}, `rule "a": the id must be unique`},
+		{[]Rule{{Id: "a", Type: RuleTypeMaxAutoscale}}, `rule "a": max is required`},
+		{[]Rule{{Id: "a", Type: RuleTypeRequireResources, Environments: []string{"[prod"}}}, `rule "a": invalid pattern "[prod"`},
+		{[]Rule{{Id: "a", Type: "foo"}}, `rule "a": unknown type "foo". Must be one of: disallowedEnvNames, disallowedTags, maxAutoscale, requireResources`},
+	}
+
+	for _, tt := range tests {
+		err := (&Policy{Rules: tt.rules}).Validate()
+
+		if tt.err == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.Equal(t, tt.err, err.Error())
+		}
+	}
+}
+
+func Test_Check(t *testing.T) {
+	policy := &Policy{
+		Rules: []Rule{
+			{Id: "no-latest", Type: RuleTypeDisallowedTags, Environments: []string{"prod", "prod-*"}},
+			{Id: "resources", Type: RuleTypeRequireResources, Environments: []string{"prod"}},
+			{Id: "max-autoscale", Type: RuleTypeMaxAutoscale, Max: util.PtrInt(5)},
+			{Id: "no-secrets", Type: RuleTypeDisallowedEnvNames},
+		},
+	}
+	app := &model.AppConfigWithOverrides{
+		AppConfig: model.AppConfig{
+			OverrideableAppConfig: model.OverrideableAppConfig{
+				Autoscale:   &model.AppConfigAutoscale{Max: util.PtrInt(10)},
+				Environment: map[string]intstr.IntOrString{"DB_PASSWORD": intstr.FromString("hunter2"), "LOG_LEVEL": intstr.FromString("info")},
+			},
+		},
+		Overrides: map[string]model.OverrideableAppConfig{
+			"prod": {
+				Autoscale: &model.AppConfigAutoscale{Max: util.PtrInt(3)},
+				Resources: &model.AppConfigResources{CpuCores: util.PtrFloat32(1)},
+			},
+		},
+	}
+
+	tests := []struct {
+		environmentName string
+		dockerTag       string
+		skip            []string
+		expected        []Violation
+	}{
+		{"", "", nil, []Violation{
+			{RuleId: "max-autoscale", Message: "autoscale.max (10) must be less than or equal to 5"},
+			{RuleId: "no-secrets", Message: `the environment variable "DB_PASSWORD" looks like a secret. Use "riser secrets save" instead`},
+		}},
+		{"dev", "latest", []string{"no-secrets"}, []Violation{
+			{RuleId: "max-autoscale", Message: "autoscale.max (10) must be less than or equal to 5"},
+		}},
+		{"prod-eu", "latest", []string{"no-secrets", "max-autoscale"}, []Violation{
+			{RuleId: "no-latest", Message: `the docker tag "latest" is not allowed`},
+		}},
+		// The prod override sets autoscale.max and cpuCores
+		{"prod", "v1", []string{"no-secrets"}, []Violation{
+			{RuleId: "resources", Message: "resources.memoryMB is required"},
+		}},
+	}
+
+	for _, tt := range tests {
+		result, err := policy.Check(app, tt.environmentName, tt.dockerTag, tt.skip)
+
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, result, tt.environmentName)
+	}
+}
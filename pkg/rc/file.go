@@ -0,0 +1,70 @@
+package rc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const defaultRcFileMode = 0644
+
+// withRcLock holds an exclusive advisory lock for the duration of fn. A separate lock file is used since the rc file
+// itself is replaced on every write.
+func withRcLock(rcPath string, fn func() error) error {
+	lock, err := os.OpenFile(rcPath+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return errors.Wrap(err, "error opening rc lock file")
+	}
+	defer lock.Close()
+
+	err = lockFile(lock)
+	if err != nil {
+		return errors.Wrap(err, "error locking rc file")
+	}
+	defer func() { _ = unlockFile(lock) }()
+
+	return fn()
+}
+
+// writeRc atomically writes the rc by writing to a temp file in the same directory and renaming it over the rc file.
+// The file mode of an existing rc file is preserved.
+func writeRc(rcPath string, rc *RuntimeConfiguration) error {
+	rc.APIVersion = CurrentAPIVersion
+	rcBytes, err := yaml.Marshal(rc)
+	if err != nil {
+		return err
+	}
+
+	fileMode := os.FileMode(defaultRcFileMode)
+	if info, err := os.Stat(rcPath); err == nil {
+		fileMode = info.Mode().Perm()
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(rcPath), filepath.Base(rcPath)+".tmp")
+	if err != nil {
+		return err
+	}
+	// Clean up on failure. This is a noop after a successful rename.
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(rcBytes)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tmpFile.Name(), fileMode)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), rcPath)
+}
@@ -0,0 +1,91 @@
+package rc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_writeRc(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "riserrc")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	rcPath := filepath.Join(tmpDir, ".riserrc")
+	rc := &RuntimeConfiguration{}
+	rc.SetContext(&Context{Name: "a", ServerURL: "https://riser.up"})
+
+	err = writeRc(rcPath, rc)
+
+	require.NoError(t, err)
+	result, err := loadAndParseRc(rcPath)
+	require.NoError(t, err)
+	assert.Equal(t, CurrentAPIVersion, result.APIVersion)
+	assert.Equal(t, "a", result.CurrentContextName)
+	assert.Equal(t, "https://riser.up", result.contextMap["a"].ServerURL)
+	// Only the rc file should remain
+	files, err := ioutil.ReadDir(tmpDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, os.FileMode(defaultRcFileMode), files[0].Mode().Perm())
+}
+
+func Test_writeRc_PreservesFileMode(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "riserrc")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	rcPath := filepath.Join(tmpDir, ".riserrc")
+	require.NoError(t, ioutil.WriteFile(rcPath, []byte{}, 0600))
+
+	err = writeRc(rcPath, &RuntimeConfiguration{})
+
+	require.NoError(t, err)
+	info, err := os.Stat(rcPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func Test_updateRc_KeepsChangesFromOtherProcesses(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "riserrc")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	rcPath := filepath.Join(tmpDir, ".riserrc")
+	rc := &RuntimeConfiguration{}
+	// Simulate another process saving a context after this rc was loaded
+	other := &RuntimeConfiguration{}
+	other.SetContext(&Context{Name: "a"})
+	require.NoError(t, writeRc(rcPath, other))
+
+	err = updateRc(rcPath, rc, func(latest *RuntimeConfiguration) error {
+		latest.SetContext(&Context{Name: "b"})
+		return nil
+	})
+
+	require.NoError(t, err)
+	result, err := loadAndParseRc(rcPath)
+	require.NoError(t, err)
+	require.Len(t, result.GetContexts(), 2)
+	assert.Equal(t, "b", result.CurrentContextName)
+	// The in-memory rc is updated
+	require.Len(t, rc.GetContexts(), 2)
+	assert.Equal(t, "b", rc.CurrentContextName)
+}
+
+func Test_updateRc_DoesNotWrite_WhenUpdateReturnsError(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "riserrc")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	rcPath := filepath.Join(tmpDir, ".riserrc")
+	rc := &RuntimeConfiguration{}
+
+	err = updateRc(rcPath, rc, func(latest *RuntimeConfiguration) error {
+		return latest.RemoveContext("missing")
+	})
+
+	assert.Equal(t, "a context with the name \"missing\" does not exist", err.Error())
+	_, err = os.Stat(rcPath)
+	assert.True(t, os.IsNotExist(err))
+}
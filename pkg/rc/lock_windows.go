@@ -0,0 +1,21 @@
+//go:build windows
+// +build windows
+
+package rc
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// Lock the maximum range so that the entire file is locked regardless of size
+const lockRange = ^uint32(0)
+
+func lockFile(file *os.File) error {
+	return windows.LockFileEx(windows.Handle(file.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, lockRange, lockRange, &windows.Overlapped{})
+}
+
+func unlockFile(file *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, lockRange, lockRange, &windows.Overlapped{})
+}
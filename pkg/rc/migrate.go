@@ -0,0 +1,67 @@
+package rc
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+// CurrentAPIVersion is the rc schema version written by this version of riser
+const CurrentAPIVersion = "v1"
+
+// legacyAPIVersion is the version of an rc file written before the apiVersion field was introduced
+const legacyAPIVersion = ""
+
+type rcMigration struct {
+	toAPIVersion string
+	migrate      func(rawRc map[string]interface{}) error
+}
+
+// rcMigrations is keyed by the apiVersion that the migration upgrades from. Migrations are applied in sequence until
+// the rc is at the CurrentAPIVersion.
+var rcMigrations = map[string]rcMigration{
+	legacyAPIVersion: {
+		toAPIVersion: "v1",
+		// v1 only introduces the apiVersion field
+		migrate: func(map[string]interface{}) error { return nil },
+	},
+}
+
+// migrateRc upgrades rc bytes from an older apiVersion to the CurrentAPIVersion. This must happen before strict
+// unmarshalling since older versions may contain fields that are no longer known.
+func migrateRc(rcBytes []byte) ([]byte, error) {
+	header := struct {
+		APIVersion string `yaml:"apiVersion"`
+	}{}
+	err := yaml.Unmarshal(rcBytes, &header)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.APIVersion == CurrentAPIVersion {
+		return rcBytes, nil
+	}
+
+	rawRc := map[string]interface{}{}
+	err = yaml.Unmarshal(rcBytes, &rawRc)
+	if err != nil {
+		return nil, err
+	}
+
+	apiVersion := header.APIVersion
+	for apiVersion != CurrentAPIVersion {
+		migration, ok := rcMigrations[apiVersion]
+		if !ok {
+			return nil, fmt.Errorf("the rc file apiVersion %q is not supported by this version of riser. You may need to upgrade riser", apiVersion)
+		}
+
+		err = migration.migrate(rawRc)
+		if err != nil {
+			return nil, fmt.Errorf("error migrating the rc file from apiVersion %q to %q: %v", apiVersion, migration.toAPIVersion, err)
+		}
+		apiVersion = migration.toAPIVersion
+		rawRc["apiVersion"] = apiVersion
+	}
+
+	return yaml.Marshal(rawRc)
+}
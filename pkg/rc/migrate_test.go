@@ -0,0 +1,43 @@
+package rc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func Test_migrateRc_Legacy(t *testing.T) {
+	legacyRc := `currentContext: a
+contexts:
+  - name: a
+    serverUrl: https://riser.up
+`
+
+	result, err := migrateRc([]byte(legacyRc))
+
+	require.NoError(t, err)
+	rc := &RuntimeConfiguration{}
+	require.NoError(t, yaml.UnmarshalStrict(result, rc))
+	assert.Equal(t, CurrentAPIVersion, rc.APIVersion)
+	assert.Equal(t, "a", rc.CurrentContextName)
+	require.Len(t, rc.Contexts, 1)
+	assert.Equal(t, "https://riser.up", rc.Contexts[0].ServerURL)
+}
+
+func Test_migrateRc_CurrentVersion_Unchanged(t *testing.T) {
+	currentRc := "apiVersion: v1\ncurrentContext: a\n"
+
+	result, err := migrateRc([]byte(currentRc))
+
+	assert.NoError(t, err)
+	assert.Equal(t, currentRc, string(result))
+}
+
+func Test_migrateRc_ReturnsError_WhenUnsupportedVersion(t *testing.T) {
+	result, err := migrateRc([]byte("apiVersion: v99\n"))
+
+	assert.Nil(t, result)
+	assert.Equal(t, `the rc file apiVersion "v99" is not supported by this version of riser. You may need to upgrade riser`, err.Error())
+}
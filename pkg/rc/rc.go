@@ -5,6 +5,9 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"riser/pkg/policy"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 
@@ -13,9 +16,13 @@ import (
 
 // RuntimeConfiguration provides configuration for the client
 type RuntimeConfiguration struct {
+	// APIVersion is the schema version of the rc file. See migrateRc.
+	APIVersion         string    `yaml:"apiVersion"`
 	CurrentContextName string    `yaml:"currentContext,omitempty"`
 	Contexts           []Context `yaml:"contexts,omitempty"`
 	contextMap         map[string]Context
+	// contextOverride takes precedence over the CurrentContextName and is never persisted
+	contextOverride string
 }
 
 // Context represents all configuration related to a particular environment
@@ -30,21 +37,74 @@ type Context struct {
 	Secure *bool `yaml:"secure,omitempty"`
 	// DemoGatewayIP is used by the demo to facilitate local installations without DNS
 	DemoGatewayIP string `yaml:"demoGatewayIp,omitempty"`
+	// DefaultNamespace is used when a namespace is not specified by a flag or by the app config
+	DefaultNamespace string `yaml:"defaultNamespace,omitempty"`
+	// ProtectedEnvironments are glob patterns (e.g. "prod*") for environments that require confirmation before changes are applied
+	ProtectedEnvironments []string `yaml:"protectedEnvironments,omitempty"`
+	// FreezeWindows are periods of time during which deploys, rollouts, and deletes are not allowed
+	FreezeWindows []policy.FreezeWindow `yaml:"freezeWindows,omitempty"`
 }
 
-// SaveRc saves a runtime configuration
+// IsSecure returns true unless TLS verification has been explicitly disabled
+func (context *Context) IsSecure() bool {
+	return context.Secure == nil || *context.Secure
+}
+
+// IsProtectedEnvironment returns true if the environment name matches any of the context's protected environment patterns
+func (context *Context) IsProtectedEnvironment(environmentName string) bool {
+	for _, pattern := range context.ProtectedEnvironments {
+		if matched, _ := path.Match(pattern, environmentName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// SaveRc saves a runtime configuration, overwriting any changes made by other processes since the rc was loaded.
+// Use UpdateRc when modifying an existing rc.
 func SaveRc(rc *RuntimeConfiguration) error {
 	rcPath, err := getRcPath()
 	if err != nil {
 		return err
 	}
 
-	rcBytes, err := yaml.Marshal(rc)
+	return withRcLock(rcPath, func() error {
+		return writeRc(rcPath, rc)
+	})
+}
+
+// UpdateRc applies a change to the rc file while holding an advisory lock. The rc file is reloaded from disk before the
+// update is applied so that changes made by other riser processes are not lost. On success the in-memory rc is replaced
+// with the updated rc.
+func UpdateRc(rc *RuntimeConfiguration, update func(latest *RuntimeConfiguration) error) error {
+	rcPath, err := getRcPath()
 	if err != nil {
 		return err
 	}
 
-	return ioutil.WriteFile(rcPath, rcBytes, 0644)
+	return updateRc(rcPath, rc, update)
+}
+
+func updateRc(rcPath string, rc *RuntimeConfiguration, update func(latest *RuntimeConfiguration) error) error {
+	return withRcLock(rcPath, func() error {
+		latest, err := loadAndParseRc(rcPath)
+		if err != nil {
+			return err
+		}
+
+		err = update(latest)
+		if err != nil {
+			return err
+		}
+
+		err = writeRc(rcPath, latest)
+		if err != nil {
+			return err
+		}
+
+		rc.replace(latest)
+		return nil
+	})
 }
 
 // LoadRc loads runtime configuration from the HOME directory
@@ -66,6 +126,11 @@ func loadAndParseRc(rcPath string) (*RuntimeConfiguration, error) {
 			return nil, err
 		}
 
+		rcBytes, err = migrateRc(rcBytes)
+		if err != nil {
+			return nil, err
+		}
+
 		err = yaml.UnmarshalStrict(rcBytes, &rc)
 		if err != nil {
 			return nil, err
@@ -75,22 +140,37 @@ func loadAndParseRc(rcPath string) (*RuntimeConfiguration, error) {
 		return rc, nil
 	}
 
-	return &RuntimeConfiguration{}, nil
+	return &RuntimeConfiguration{APIVersion: CurrentAPIVersion}, nil
+}
+
+// replace replaces the persisted state with that of another rc. The context override is kept if the context still exists.
+func (rc *RuntimeConfiguration) replace(other *RuntimeConfiguration) {
+	rc.APIVersion = other.APIVersion
+	rc.CurrentContextName = other.CurrentContextName
+	rc.Contexts = other.Contexts
+	rc.contextMap = other.contextMap
+	if _, found := rc.contextMap[rc.contextOverride]; !found {
+		rc.contextOverride = ""
+	}
 }
 
 // CurrentContext returns the current context.
 func (rc *RuntimeConfiguration) CurrentContext() (*Context, error) {
 	var context *Context
-	if rc.CurrentContextName == "" {
+	contextName := rc.CurrentContextName
+	if rc.contextOverride != "" {
+		contextName = rc.contextOverride
+	}
+	if contextName == "" {
 		return nil, contextError("no context set. Use \"riser context current <contextName>\" to set the context")
 	} else {
-		context = rc.getContextByName(rc.CurrentContextName)
+		context = rc.getContextByName(contextName)
 	}
 	if context != nil {
 		return context, nil
 	}
 
-	return nil, contextError(fmt.Sprintf("context \"%s\" does not exist", rc.CurrentContextName))
+	return nil, contextError(fmt.Sprintf("context \"%s\" does not exist", contextName))
 }
 
 func (rc *RuntimeConfiguration) getContextByName(name string) *Context {
@@ -105,27 +185,53 @@ func contextError(errorMessage string) error {
 	return fmt.Errorf("Unable to load current context: %s", errorMessage)
 }
 
-// SetCurrentContext sets the current context
+// SetCurrentContext sets the current context. Any context override is cleared.
 func (rc *RuntimeConfiguration) SetCurrentContext(contextName string) error {
 	_, found := rc.contextMap[contextName]
 	if found {
 		rc.CurrentContextName = contextName
+		rc.contextOverride = ""
 		return nil
 	}
 
 	return fmt.Errorf("Context \"%s\" does not exist", contextName)
 }
 
-// GetContexts returns all configured contexts
+// OverrideCurrentContext uses the specified context in place of the current context for the lifetime of the process.
+// Unlike SetCurrentContext, the override is not persisted when the rc is saved.
+func (rc *RuntimeConfiguration) OverrideCurrentContext(contextName string) error {
+	_, found := rc.contextMap[contextName]
+	if found {
+		rc.contextOverride = contextName
+		return nil
+	}
+
+	return fmt.Errorf("Context \"%s\" does not exist", contextName)
+}
+
+// GetContexts returns all configured contexts sorted by name
 func (rc *RuntimeConfiguration) GetContexts() []Context {
 	values := []Context{}
 	for _, value := range rc.contextMap {
 		values = append(values, value)
 	}
 
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Name < values[j].Name
+	})
+
 	return values
 }
 
+// GetContext returns a context by name
+func (rc *RuntimeConfiguration) GetContext(contextName string) (*Context, error) {
+	context := rc.getContextByName(contextName)
+	if context == nil {
+		return nil, fmt.Errorf("Context \"%s\" does not exist", contextName)
+	}
+	return context, nil
+}
+
 // SetContext adds or updates a context and sets the current context to the recently saved context
 func (rc *RuntimeConfiguration) SetContext(context *Context) {
 	if rc.contextMap == nil {
@@ -134,10 +240,22 @@ func (rc *RuntimeConfiguration) SetContext(context *Context) {
 
 	rc.contextMap[context.Name] = *context
 	rc.CurrentContextName = context.Name
+	rc.contextOverride = ""
 
 	rc.Contexts = rc.GetContexts()
 }
 
+// UpdateContext updates an existing context without changing the current context
+func (rc *RuntimeConfiguration) UpdateContext(context *Context) error {
+	if _, found := rc.contextMap[context.Name]; !found {
+		return fmt.Errorf("a context with the name \"%s\" does not exist", context.Name)
+	}
+
+	rc.contextMap[context.Name] = *context
+	rc.Contexts = rc.GetContexts()
+	return nil
+}
+
 // RemoveContext removes a context
 func (rc *RuntimeConfiguration) RemoveContext(contextName string) error {
 	_, found := rc.contextMap[contextName]
@@ -154,6 +272,35 @@ func (rc *RuntimeConfiguration) RemoveContext(contextName string) error {
 	return nil
 }
 
+// RenameContext renames a context. The current context is updated if it refers to the renamed context.
+func (rc *RuntimeConfiguration) RenameContext(contextName, newContextName string) error {
+	if strings.TrimSpace(newContextName) == "" {
+		return errors.New("the new context name must not be empty")
+	}
+
+	context, found := rc.contextMap[contextName]
+	if !found {
+		return fmt.Errorf("a context with the name \"%s\" does not exist", contextName)
+	}
+
+	if _, found = rc.contextMap[newContextName]; found {
+		return fmt.Errorf("a context with the name \"%s\" already exists", newContextName)
+	}
+
+	delete(rc.contextMap, contextName)
+	context.Name = newContextName
+	rc.contextMap[newContextName] = context
+	if rc.CurrentContextName == contextName {
+		rc.CurrentContextName = newContextName
+	}
+	if rc.contextOverride == contextName {
+		rc.contextOverride = newContextName
+	}
+
+	rc.Contexts = rc.GetContexts()
+	return nil
+}
+
 func toContextMap(contexts []Context) map[string]Context {
 	contextMap := map[string]Context{}
 
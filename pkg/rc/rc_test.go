@@ -97,6 +97,58 @@ func Test_SetCurrentContext_ReturnsError_WhenInvalidContext(t *testing.T) {
 	assert.Equal(t, "Context \"invalid\" does not exist", err.Error())
 }
 
+func Test_OverrideCurrentContext(t *testing.T) {
+	rc := RuntimeConfiguration{
+		contextMap: toContextMap([]Context{
+			{Name: "a"},
+			{Name: "b"},
+		}),
+
+		CurrentContextName: "a",
+	}
+
+	err := rc.OverrideCurrentContext("b")
+	result, contextErr := rc.CurrentContext()
+
+	assert.NoError(t, err)
+	assert.NoError(t, contextErr)
+	assert.Equal(t, "b", result.Name)
+	// The override must not change the persisted current context
+	assert.Equal(t, "a", rc.CurrentContextName)
+}
+
+func Test_OverrideCurrentContext_ReturnsError_WhenInvalidContext(t *testing.T) {
+	rc := RuntimeConfiguration{
+		contextMap: toContextMap([]Context{
+			{Name: "a"},
+		}),
+
+		CurrentContextName: "a",
+	}
+
+	err := rc.OverrideCurrentContext("invalid")
+	result, _ := rc.CurrentContext()
+
+	assert.Equal(t, "Context \"invalid\" does not exist", err.Error())
+	assert.Equal(t, "a", result.Name)
+}
+
+func Test_SetCurrentContext_ClearsOverride(t *testing.T) {
+	rc := RuntimeConfiguration{
+		contextMap: toContextMap([]Context{
+			{Name: "a"},
+			{Name: "b"},
+		}),
+		contextOverride: "b",
+	}
+
+	err := rc.SetCurrentContext("a")
+	result, _ := rc.CurrentContext()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a", result.Name)
+}
+
 func Test_loadAndParse(t *testing.T) {
 	rc := `currentContext: a
 contexts:
@@ -168,6 +220,32 @@ func Test_SaveContext_SetsCurrentContext_WhenNotSet(t *testing.T) {
 	assert.Equal(t, "a", rc.CurrentContextName)
 }
 
+func Test_UpdateContext_DoesNotChangeCurrentContext(t *testing.T) {
+	rc := &RuntimeConfiguration{
+		contextMap: toContextMap([]Context{
+			{Name: "a"},
+			{Name: "b", ServerURL: "URLb"},
+		}),
+
+		CurrentContextName: "a",
+	}
+
+	err := rc.UpdateContext(&Context{Name: "b", ServerURL: "modified"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a", rc.CurrentContextName)
+	assert.Equal(t, "modified", rc.contextMap["b"].ServerURL)
+	assert.Equal(t, "modified", rc.Contexts[1].ServerURL)
+}
+
+func Test_UpdateContext_ReturnsError_WhenContextDoesNotExist(t *testing.T) {
+	rc := &RuntimeConfiguration{}
+
+	err := rc.UpdateContext(&Context{Name: "a"})
+
+	assert.Equal(t, "a context with the name \"a\" does not exist", err.Error())
+}
+
 func Test_RemoveContext(t *testing.T) {
 	rc := RuntimeConfiguration{
 		contextMap: toContextMap([]Context{
@@ -194,3 +272,93 @@ func Test_RemoveContext_ReturnsError_WhenContextDoesNotExist(t *testing.T) {
 
 	assert.Equal(t, "a context with the name \"a\" does not exist", result.Error())
 }
+
+func Test_GetContexts_SortedByName(t *testing.T) {
+	rc := RuntimeConfiguration{
+		contextMap: toContextMap([]Context{
+			{Name: "c"},
+			{Name: "a"},
+			{Name: "b"},
+		}),
+	}
+
+	result := rc.GetContexts()
+
+	require.Len(t, result, 3)
+	assert.Equal(t, "a", result[0].Name)
+	assert.Equal(t, "b", result[1].Name)
+	assert.Equal(t, "c", result[2].Name)
+}
+
+func Test_RenameContext(t *testing.T) {
+	rc := RuntimeConfiguration{
+		contextMap: toContextMap([]Context{
+			{Name: "a", ServerURL: "URLa"},
+			{Name: "b"},
+		}),
+
+		CurrentContextName: "a",
+	}
+
+	err := rc.RenameContext("a", "c")
+
+	assert.NoError(t, err)
+	_, found := rc.contextMap["a"]
+	assert.False(t, found, "old context should be removed")
+	assert.Equal(t, "c", rc.contextMap["c"].Name)
+	assert.Equal(t, "URLa", rc.contextMap["c"].ServerURL)
+	assert.Equal(t, "c", rc.CurrentContextName)
+	require.Len(t, rc.Contexts, 2)
+	assert.Equal(t, "b", rc.Contexts[0].Name)
+	assert.Equal(t, "c", rc.Contexts[1].Name)
+}
+
+func Test_RenameContext_ReturnsError_WhenContextDoesNotExist(t *testing.T) {
+	rc := RuntimeConfiguration{}
+
+	err := rc.RenameContext("a", "b")
+
+	assert.Equal(t, "a context with the name \"a\" does not exist", err.Error())
+}
+
+func Test_RenameContext_ReturnsError_WhenNewContextIsEmpty(t *testing.T) {
+	rc := RuntimeConfiguration{
+		contextMap: toContextMap([]Context{
+			{Name: "a"},
+		}),
+	}
+
+	err := rc.RenameContext("a", " ")
+
+	assert.Equal(t, "the new context name must not be empty", err.Error())
+	assert.Equal(t, "a", rc.contextMap["a"].Name)
+}
+
+func Test_RenameContext_ReturnsError_WhenNewContextExists(t *testing.T) {
+	rc := RuntimeConfiguration{
+		contextMap: toContextMap([]Context{
+			{Name: "a"},
+			{Name: "b"},
+		}),
+	}
+
+	err := rc.RenameContext("a", "b")
+
+	assert.Equal(t, "a context with the name \"b\" already exists", err.Error())
+	assert.Equal(t, "a", rc.contextMap["a"].Name)
+}
+
+func Test_Context_IsSecure(t *testing.T) {
+	secure := false
+	assert.True(t, (&Context{}).IsSecure())
+	assert.False(t, (&Context{Secure: &secure}).IsSecure())
+}
+
+func Test_IsProtectedEnvironment(t *testing.T) {
+	context := &Context{ProtectedEnvironments: []string{"prod", "prod-*"}}
+
+	assert.True(t, context.IsProtectedEnvironment("prod"))
+	assert.True(t, context.IsProtectedEnvironment("prod-eu"))
+	assert.False(t, context.IsProtectedEnvironment("dev"))
+	assert.False(t, (&Context{}).IsProtectedEnvironment("prod"))
+}
@@ -8,7 +8,7 @@ import (
 
 type RevisionStatusWithTraffic struct {
 	model.DeploymentRevisionStatus
-	Traffic model.DeploymentTrafficStatus
+	Traffic model.DeploymentTrafficStatus `json:"traffic"`
 }
 
 /*
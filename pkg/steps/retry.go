@@ -41,7 +41,8 @@ func (step *RetryStep) Exec() error {
 			break
 		}
 
-		logger.Log().Verbose(fmt.Sprintf("Step %q failed and will be retried. Error: %v", step.Name, stepErr))
+		logger.WithFields(logger.Fields{"step": step.Name, "attempt": step.attempts, "maxAttempts": step.maxAttempts, "error": stepErr.Error()}).
+			Verbose(fmt.Sprintf("Step %q failed and will be retried. Error: %v", step.Name, stepErr))
 		time.Sleep(step.sleepTime)
 	}
 
@@ -3,9 +3,10 @@ package steps
 import (
 	"fmt"
 	"riser/pkg/logger"
+	"riser/pkg/ui/style"
+	"time"
 
 	"github.com/pkg/errors"
-	"github.com/wzshiming/ctc"
 )
 
 type Step interface {
@@ -20,12 +21,14 @@ type StepMeta struct {
 
 func Run(steps ...Step) error {
 	for _, step := range steps {
-		logger.Log().Info(styleStepExecute(step.Meta()))
+		logger.WithFields(logger.Fields{"step": step.Meta().Name}).Info(styleStepExecute(step.Meta()))
+		start := time.Now()
 		err := step.Exec()
 		if err != nil {
 			return errors.Wrap(err, styleStepError(step.Meta()))
 		}
-		logger.Log().Info(styleStepComplete(step.Meta()))
+		logger.WithFields(logger.Fields{"step": step.Meta().Name, "elapsedMs": time.Since(start).Milliseconds()}).
+			Info(styleStepComplete(step.Meta()))
 	}
 
 	return nil
@@ -34,14 +37,14 @@ func Run(steps ...Step) error {
 func styleStepExecute(stepMeta StepMeta) string {
 	return fmt.Sprintf(
 		"Executing %s...",
-		fmt.Sprint(ctc.ForegroundBrightCyan, stepMeta.Name, ctc.Reset),
+		style.Emphasis(stepMeta.Name),
 	)
 }
 
 func styleStepComplete(stepMeta StepMeta) string {
-	return fmt.Sprint(ctc.ForegroundBrightGreen, "✔", ctc.Reset, " Complete")
+	return fmt.Sprint(style.Good("✔"), " Complete")
 }
 
 func styleStepError(stepMeta StepMeta) string {
-	return fmt.Sprint(ctc.ForegroundBrightRed, "✘", ctc.Reset, " Error executing step ", ctc.ForegroundBrightRed, stepMeta.Name, ctc.Reset)
+	return fmt.Sprint(style.Bad("✘"), " Error executing step ", style.Bad(stepMeta.Name))
 }
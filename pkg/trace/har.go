@@ -0,0 +1,167 @@
+package trace
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HAR types are a subset of the HAR 1.2 spec: http://www.softwareishard.com/blog/har-12-spec/
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string      `json:"version"`
+	Creator harCreator  `json:"creator"`
+	Entries []*harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            int64       `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	// Error is a custom field (custom fields must start with an underscore) for requests that did not receive a response
+	Error string `json:"_error,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    int64 `json:"send"`
+	Wait    int64 `json:"wait"`
+	Receive int64 `json:"receive"`
+}
+
+// HARRecorder records requests to a HAR file. The file is rewritten after each request so that it is complete even if the
+// process exits unexpectedly.
+type HARRecorder struct {
+	path  string
+	har   harFile
+	mutex sync.Mutex
+}
+
+// NewHARRecorder creates a recorder that writes to the path
+func NewHARRecorder(path string, riserVersion string) *HARRecorder {
+	return &HARRecorder{
+		path: path,
+		har: harFile{
+			Log: harLog{
+				Version: "1.2",
+				Creator: harCreator{Name: "riser", Version: riserVersion},
+				Entries: []*harEntry{},
+			},
+		},
+	}
+}
+
+func (recorder *HARRecorder) record(exchange *exchange) error {
+	entry := &harEntry{
+		StartedDateTime: exchange.start.Format(time.RFC3339Nano),
+		Time:            exchange.latency.Milliseconds(),
+		Request: harRequest{
+			Method:      exchange.method,
+			URL:         exchange.url,
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     toHarNameValues(exchange.requestHeaders),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    exchange.requestBodySize,
+		},
+		Response: harResponse{
+			Status:      exchange.status,
+			StatusText:  http.StatusText(exchange.status),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     toHarNameValues(exchange.responseHeaders),
+			Content: harContent{
+				Size:     exchange.responseBodySize,
+				MimeType: exchange.responseHeaders.Get("Content-Type"),
+				Text:     exchange.responseBody,
+			},
+			HeadersSize: -1,
+			BodySize:    exchange.responseBodySize,
+		},
+		Timings: harTimings{Send: 0, Wait: exchange.latency.Milliseconds(), Receive: 0},
+	}
+
+	if exchange.requestBody != "" {
+		entry.Request.PostData = &harPostData{MimeType: exchange.requestHeaders.Get("Content-Type"), Text: exchange.requestBody}
+	}
+
+	if exchange.err != nil {
+		entry.Error = exchange.err.Error()
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.har.Log.Entries = append(recorder.har.Log.Entries, entry)
+	harBytes, err := json.MarshalIndent(recorder.har, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(recorder.path, harBytes, 0600)
+}
+
+func toHarNameValues(headers http.Header) []harNameValue {
+	nameValues := []harNameValue{}
+	for name, values := range headers {
+		for _, value := range values {
+			nameValues = append(nameValues, harNameValue{Name: name, Value: value})
+		}
+	}
+	sort.SliceStable(nameValues, func(i, j int) bool {
+		return nameValues[i].Name < nameValues[j].Name
+	})
+	return nameValues
+}
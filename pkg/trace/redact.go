@@ -0,0 +1,98 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces sensitive values
+const Redacted = "REDACTED"
+
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// sensitiveJsonKeys are lowercase JSON keys whose values are always redacted (e.g. the plain text value of a secret)
+var sensitiveJsonKeys = map[string]bool{
+	"apikey":         true,
+	"password":       true,
+	"plaintextvalue": true,
+	"token":          true,
+}
+
+// Redactor masks sensitive values such as the API key
+type Redactor struct {
+	secrets []string
+}
+
+// AddSecret adds a value that is always masked (e.g. an API key)
+func (redactor *Redactor) AddSecret(secret string) {
+	if secret != "" {
+		redactor.secrets = append(redactor.secrets, secret)
+	}
+}
+
+// Headers returns a copy of the headers with sensitive values masked
+func (redactor *Redactor) Headers(headers http.Header) http.Header {
+	redacted := http.Header{}
+	for name, values := range headers {
+		for _, value := range values {
+			if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+				value = Redacted
+			} else {
+				value = redactor.String(value)
+			}
+			redacted.Add(name, value)
+		}
+	}
+	return redacted
+}
+
+// Body returns the body with sensitive values masked. The values of sensitive keys are masked in JSON bodies.
+func (redactor *Redactor) Body(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var decoded interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if decoder.Decode(&decoded) == nil {
+		if redactedBody, err := json.Marshal(redactJson(decoded)); err == nil {
+			body = redactedBody
+		}
+	}
+
+	return redactor.String(string(body))
+}
+
+// String masks secrets in a string
+func (redactor *Redactor) String(value string) string {
+	for _, secret := range redactor.secrets {
+		value = strings.ReplaceAll(value, secret, Redacted)
+	}
+	return value
+}
+
+func redactJson(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range typed {
+			if sensitiveJsonKeys[strings.ToLower(key)] {
+				typed[key] = Redacted
+			} else {
+				typed[key] = redactJson(nested)
+			}
+		}
+	case []interface{}:
+		for idx, nested := range typed {
+			typed[idx] = redactJson(nested)
+		}
+	}
+	return value
+}
@@ -0,0 +1,123 @@
+package trace
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"riser/pkg/logger"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Redactor_Headers(t *testing.T) {
+	redactor := &Redactor{}
+	redactor.AddSecret("myapikey")
+	headers := http.Header{}
+	headers.Add("Authorization", "Apikey: myapikey")
+	headers.Add("X-Custom", "foo myapikey")
+	headers.Add("Accept", "application/json")
+
+	result := redactor.Headers(headers)
+
+	assert.Equal(t, "REDACTED", result.Get("Authorization"))
+	assert.Equal(t, "foo REDACTED", result.Get("X-Custom"))
+	assert.Equal(t, "application/json", result.Get("Accept"))
+	assert.Equal(t, "Apikey: myapikey", headers.Get("Authorization"))
+}
+
+func Test_Redactor_Body(t *testing.T) {
+	redactor := &Redactor{}
+	redactor.AddSecret("myapikey")
+
+	tests := []struct {
+		body     string
+		expected string
+	}{
+		{"", ""},
+		{"not json myapikey", "not json REDACTED"},
+		{`{"name":"mysecret","plainTextValue":"hunter2","nested":[{"Token":"abc","count":1}]}`,
+			`{"name":"mysecret","nested":[{"Token":"REDACTED","count":1}],"plainTextValue":"REDACTED"}`},
+		{`{"message":"bad key myapikey"}`, `{"message":"bad key REDACTED"}`},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, redactor.Body([]byte(tt.body)), tt.body)
+	}
+}
+
+func Test_Transport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"plainTextValue":"hunter2"}`, string(body))
+		assert.Equal(t, "Apikey: myapikey", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	}))
+	defer server.Close()
+
+	tempDir, err := ioutil.TempDir("", "riser-trace")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	harPath := path.Join(tempDir, "trace.har")
+
+	log := logger.NewFakeLogger()
+	logger.SetDiagnosticLogger(log)
+	transport := NewTransport()
+	transport.Redactor.AddSecret("myapikey")
+	transport.Log = true
+	transport.HAR = NewHARRecorder(harPath, "1.0.0")
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/secrets", strings.NewReader(`{"plainTextValue":"hunter2"}`))
+	require.NoError(t, err)
+	req.Header.Add("Authorization", "Apikey: myapikey")
+
+	response, err := (&http.Client{Transport: transport}).Do(req)
+
+	require.NoError(t, err)
+	responseBody, _ := ioutil.ReadAll(response.Body)
+	assert.Equal(t, `{"message":"ok"}`, string(responseBody))
+
+	require.Len(t, log.InfoLogs, 1)
+	assert.Contains(t, log.InfoLogs[0], "--> POST "+server.URL+"/api/v1/secrets")
+	assert.Contains(t, log.InfoLogs[0], "Authorization: REDACTED")
+	assert.Contains(t, log.InfoLogs[0], `{"plainTextValue":"REDACTED"}`)
+	assert.Contains(t, log.InfoLogs[0], "<-- 201 POST "+server.URL+"/api/v1/secrets")
+	assert.NotContains(t, log.InfoLogs[0], "myapikey")
+	assert.NotContains(t, log.InfoLogs[0], "hunter2")
+
+	harBytes, err := ioutil.ReadFile(harPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(harBytes), "myapikey")
+	assert.NotContains(t, string(harBytes), "hunter2")
+	har := harFile{}
+	require.NoError(t, json.Unmarshal(harBytes, &har))
+	assert.Equal(t, "1.2", har.Log.Version)
+	require.Len(t, har.Log.Entries, 1)
+	assert.Equal(t, http.MethodPost, har.Log.Entries[0].Request.Method)
+	assert.Equal(t, `{"plainTextValue":"REDACTED"}`, har.Log.Entries[0].Request.PostData.Text)
+	assert.Equal(t, http.StatusCreated, har.Log.Entries[0].Response.Status)
+	assert.Equal(t, `{"message":"ok"}`, har.Log.Entries[0].Response.Content.Text)
+	assert.Equal(t, "application/json", har.Log.Entries[0].Response.Content.MimeType)
+}
+
+func Test_Transport_InsecureHost(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	logger.SetDiagnosticLogger(logger.NewFakeLogger())
+	transport := NewTransport()
+	client := &http.Client{Transport: transport}
+
+	_, err := client.Get(server.URL)
+	assert.Error(t, err)
+
+	transport.AddInsecureHost(strings.TrimPrefix(server.URL, "https://"))
+	_, err = client.Get(server.URL)
+	assert.NoError(t, err)
+}
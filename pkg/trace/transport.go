@@ -0,0 +1,177 @@
+// Package trace provides HTTP tracing for troubleshooting requests to the riser server
+package trace
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"riser/pkg/logger"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxLoggedBodyLength prevents large bodies (e.g. dry run commits) from flooding the log. HAR files always contain the full body.
+const maxLoggedBodyLength = 16 * 1024
+
+// Transport is an http.RoundTripper that logs each request and optionally records it to a HAR file.
+// Sensitive values are always redacted.
+type Transport struct {
+	Redactor *Redactor
+	// Log determines if requests are logged
+	Log bool
+	// HAR is optional
+	HAR *HARRecorder
+
+	secureBase    http.RoundTripper
+	insecureBase  http.RoundTripper
+	insecureHosts map[string]bool
+	mutex         sync.RWMutex
+}
+
+// exchange is a redacted request and response
+type exchange struct {
+	start            time.Time
+	latency          time.Duration
+	method           string
+	url              string
+	requestHeaders   http.Header
+	requestBody      string
+	requestBodySize  int
+	status           int
+	responseHeaders  http.Header
+	responseBody     string
+	responseBodySize int
+	err              error
+}
+
+// NewTransport creates a new tracing transport
+func NewTransport() *Transport {
+	return &Transport{
+		Redactor:      &Redactor{},
+		secureBase:    http.DefaultTransport,
+		insecureBase:  &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		insecureHosts: map[string]bool{},
+	}
+}
+
+// AddInsecureHost disables TLS verification for a host (e.g. for a context that is not secure). This is required since
+// the transport is shared by all clients.
+func (transport *Transport) AddInsecureHost(host string) {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	transport.insecureHosts[host] = true
+}
+
+// RoundTrip executes a single HTTP transaction
+func (transport *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	traced := &exchange{
+		start:          time.Now(),
+		method:         req.Method,
+		url:            transport.Redactor.String(req.URL.String()),
+		requestHeaders: transport.Redactor.Headers(req.Header),
+	}
+
+	if req.Body != nil {
+		requestBody, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(requestBody))
+		traced.requestBody = transport.Redactor.Body(requestBody)
+		traced.requestBodySize = len(requestBody)
+	}
+
+	response, err := transport.base(req).RoundTrip(req)
+	traced.latency = time.Since(traced.start)
+	if err != nil {
+		traced.err = err
+	} else {
+		responseBody, readErr := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		response.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+		if readErr != nil {
+			traced.err = readErr
+		}
+		traced.status = response.StatusCode
+		traced.responseHeaders = transport.Redactor.Headers(response.Header)
+		traced.responseBody = transport.Redactor.Body(responseBody)
+		traced.responseBodySize = len(responseBody)
+	}
+
+	if transport.Log {
+		logExchange(traced)
+	}
+
+	if transport.HAR != nil {
+		if harErr := transport.HAR.record(traced); harErr != nil {
+			logger.Diagnostic().Warn(fmt.Sprintf("Unable to write HAR file: %v", harErr))
+		}
+	}
+
+	return response, err
+}
+
+func (transport *Transport) base(req *http.Request) http.RoundTripper {
+	transport.mutex.RLock()
+	defer transport.mutex.RUnlock()
+	if transport.insecureHosts[req.URL.Host] {
+		return transport.insecureBase
+	}
+	return transport.secureBase
+}
+
+func logExchange(traced *exchange) {
+	fields := logger.Fields{
+		"method":         traced.method,
+		"url":            traced.url,
+		"latencyMs":      traced.latency.Milliseconds(),
+		"requestHeaders": traced.requestHeaders,
+		"requestBody":    truncateBody(traced.requestBody),
+	}
+
+	message := fmt.Sprintf("--> %s %s\n", traced.method, traced.url)
+	message += formatHeaders(traced.requestHeaders)
+	if traced.requestBody != "" {
+		message += truncateBody(traced.requestBody) + "\n"
+	}
+
+	if traced.err != nil {
+		fields["error"] = traced.err.Error()
+		message += fmt.Sprintf("<-- %s %s (%dms) %v", traced.method, traced.url, traced.latency.Milliseconds(), traced.err)
+	} else {
+		fields["status"] = traced.status
+		fields["responseHeaders"] = traced.responseHeaders
+		fields["responseBody"] = truncateBody(traced.responseBody)
+		message += fmt.Sprintf("<-- %d %s %s (%dms)\n", traced.status, traced.method, traced.url, traced.latency.Milliseconds())
+		message += formatHeaders(traced.responseHeaders)
+		message += truncateBody(traced.responseBody)
+	}
+
+	logger.DiagnosticWithFields(fields).Info(strings.TrimSuffix(message, "\n"))
+}
+
+func formatHeaders(headers http.Header) string {
+	names := []string{}
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	formatted := ""
+	for _, name := range names {
+		formatted += fmt.Sprintf("%s: %s\n", name, strings.Join(headers[name], ", "))
+	}
+	return formatted
+}
+
+func truncateBody(body string) string {
+	if len(body) > maxLoggedBodyLength {
+		return fmt.Sprintf("%s... (truncated %d bytes)", body[:maxLoggedBodyLength], len(body)-maxLoggedBodyLength)
+	}
+	return body
+}
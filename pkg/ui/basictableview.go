@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"riser/pkg/ui/table"
+	"sort"
+	"strings"
 )
 
 // BasicTableView provides provides a View for basic table layouts.
@@ -23,6 +25,69 @@ func (view *BasicTableView) AddRow(values ...interface{}) {
 	view.rows = append(view.rows, values)
 }
 
+// Filter removes all rows that do not match the selector. Selector keys are matched to the header case insensitively.
+func (view *BasicTableView) Filter(selector Selector) error {
+	for _, requirement := range selector {
+		columnIdx, err := view.columnIndex(requirement.Key)
+		if err != nil {
+			return err
+		}
+
+		rows := [][]interface{}{}
+		for _, row := range view.rows {
+			if requirement.Matches(fmt.Sprintf("%v", row[columnIdx])) {
+				rows = append(rows, row)
+			}
+		}
+		view.rows = rows
+	}
+
+	return nil
+}
+
+// SortBy sorts rows by a column. The column is matched to the header case insensitively. Prefix the column with "-" to
+// sort in descending order. Numeric values are sorted numerically; all other values are sorted as strings.
+func (view *BasicTableView) SortBy(column string) error {
+	descending := strings.HasPrefix(column, "-")
+	columnIdx, err := view.columnIndex(strings.TrimPrefix(column, "-"))
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(view.rows, func(i, j int) bool {
+		if descending {
+			return lessValue(view.rows[j][columnIdx], view.rows[i][columnIdx])
+		}
+		return lessValue(view.rows[i][columnIdx], view.rows[j][columnIdx])
+	})
+
+	return nil
+}
+
+// Paginate skips the first offset rows and then keeps up to limit rows. A limit of 0 keeps all remaining rows.
+func (view *BasicTableView) Paginate(offset, limit int) {
+	if offset >= len(view.rows) {
+		view.rows = [][]interface{}{}
+		return
+	}
+	if offset > 0 {
+		view.rows = view.rows[offset:]
+	}
+	if limit > 0 && limit < len(view.rows) {
+		view.rows = view.rows[:limit]
+	}
+}
+
+func (view *BasicTableView) columnIndex(column string) (int, error) {
+	for idx, header := range view.header {
+		if strings.EqualFold(header, column) {
+			return idx, nil
+		}
+	}
+
+	return -1, fmt.Errorf("unknown column %q. Must be one of: %s", column, strings.ToLower(strings.Join(view.header, ", ")))
+}
+
 func (view *BasicTableView) RenderHuman(writer io.Writer) error {
 	table := table.Default().Header(view.header...)
 
@@ -48,6 +113,15 @@ func (view *BasicTableView) RenderJson(writer io.Writer) error {
 	return RenderJson(data, writer)
 }
 
+func (view *BasicTableView) RenderCsv(writer io.Writer) error {
+	records := [][]string{view.header}
+	for _, row := range view.rows {
+		records = append(records, toString(row))
+	}
+
+	return RenderCsv(records, writer)
+}
+
 func toString(values []interface{}) []string {
 	arr := make([]string, len(values))
 	for i, val := range values {
@@ -56,3 +130,29 @@ func toString(values []interface{}) []string {
 
 	return arr
 }
+
+func lessValue(a, b interface{}) bool {
+	aFloat, aIsNumber := toFloat(a)
+	bFloat, bIsNumber := toFloat(b)
+	if aIsNumber && bIsNumber {
+		return aFloat < bFloat
+	}
+
+	return strings.ToLower(fmt.Sprintf("%v", a)) < strings.ToLower(fmt.Sprintf("%v", b))
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
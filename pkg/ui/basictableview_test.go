@@ -41,3 +41,79 @@ func Test_RenderJson(t *testing.T) {
 
 	assert.Regexp(t, `[{"H1":"h1v1","H2":"h1v2"},{"H1":"h2v1","H2":"h2v2"}]`, b.String())
 }
+
+func Test_BasicTableView_Filter(t *testing.T) {
+	view := &BasicTableView{}
+	view.Header("Name", "Namespace")
+	view.AddRow("api", "apps")
+	view.AddRow("api-v2", "other")
+	view.AddRow("web", "apps")
+
+	selector, err := ParseSelector("name~=^api,NAMESPACE=apps")
+	assert.NoError(t, err)
+
+	err = view.Filter(selector)
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]interface{}{{"api", "apps"}}, view.rows)
+}
+
+func Test_BasicTableView_Filter_UnknownColumn(t *testing.T) {
+	view := &BasicTableView{}
+	view.Header("Name", "Namespace")
+
+	err := view.Filter(Selector{{Key: "foo", Operator: SelectorOperatorEquals, Value: "bar"}})
+
+	assert.Equal(t, `unknown column "foo". Must be one of: name, namespace`, err.Error())
+}
+
+func Test_BasicTableView_SortBy(t *testing.T) {
+	tests := []struct {
+		column   string
+		expected [][]interface{}
+	}{
+		{"name", [][]interface{}{{"a", 10}, {"B", 2}, {"c", 1}}},
+		{"-Name", [][]interface{}{{"c", 1}, {"B", 2}, {"a", 10}}},
+		// Numbers sort numerically rather than as strings
+		{"count", [][]interface{}{{"c", 1}, {"B", 2}, {"a", 10}}},
+	}
+
+	for _, tt := range tests {
+		view := &BasicTableView{}
+		view.Header("Name", "Count")
+		view.AddRow("B", 2)
+		view.AddRow("a", 10)
+		view.AddRow("c", 1)
+
+		err := view.SortBy(tt.column)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, view.rows, tt.column)
+	}
+}
+
+func Test_BasicTableView_Paginate(t *testing.T) {
+	tests := []struct {
+		offset   int
+		limit    int
+		expected [][]interface{}
+	}{
+		{0, 0, [][]interface{}{{"a"}, {"b"}, {"c"}}},
+		{0, 2, [][]interface{}{{"a"}, {"b"}}},
+		{1, 0, [][]interface{}{{"b"}, {"c"}}},
+		{2, 5, [][]interface{}{{"c"}}},
+		{3, 1, [][]interface{}{}},
+	}
+
+	for _, tt := range tests {
+		view := &BasicTableView{}
+		view.Header("Name")
+		view.AddRow("a")
+		view.AddRow("b")
+		view.AddRow("c")
+
+		view.Paginate(tt.offset, tt.limit)
+
+		assert.Equal(t, tt.expected, view.rows)
+	}
+}
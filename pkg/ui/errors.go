@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+	"github.com/riser-platform/riser-server/pkg/sdk"
+)
+
+// ErrorCode is a stable identifier for a category of error. Each code maps to a distinct exit code.
+type ErrorCode string
+
+const (
+	ErrorCodeGeneral      ErrorCode = "General"
+	ErrorCodeUsage        ErrorCode = "Usage"
+	ErrorCodeValidation   ErrorCode = "ValidationFailed"
+	ErrorCodeNotFound     ErrorCode = "NotFound"
+	ErrorCodeAuth         ErrorCode = "AuthFailed"
+	ErrorCodeTimeout      ErrorCode = "Timeout"
+	ErrorCodeConflict     ErrorCode = "Conflict"
+	ErrorCodeServer       ErrorCode = "ServerError"
+	ErrorCodeConnection   ErrorCode = "ConnectionFailed"
+	ErrorCodeIncompatible ErrorCode = "Incompatible"
+	ErrorCodeUnhealthy    ErrorCode = "Unhealthy"
+)
+
+var exitCodes = map[ErrorCode]int{
+	ErrorCodeGeneral:      1,
+	ErrorCodeUsage:        2,
+	ErrorCodeValidation:   3,
+	ErrorCodeNotFound:     4,
+	ErrorCodeAuth:         5,
+	ErrorCodeTimeout:      6,
+	ErrorCodeConflict:     7,
+	ErrorCodeServer:       8,
+	ErrorCodeConnection:   9,
+	ErrorCodeIncompatible: 10,
+	ErrorCodeUnhealthy:    11,
+}
+
+// Error is an error with a stable code for machine readable output
+type Error struct {
+	Code    ErrorCode
+	Message string
+	// Details provides optional structured data about the error (e.g. validation errors)
+	Details interface{}
+	Err     error
+}
+
+// NewError creates a new Error
+func NewError(code ErrorCode, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func (err *Error) Error() string {
+	return err.Message
+}
+
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// ExitCode returns the process exit code for the error
+func (err *Error) ExitCode() int {
+	if exitCode, ok := exitCodes[err.Code]; ok {
+		return exitCode
+	}
+	return exitCodes[ErrorCodeGeneral]
+}
+
+type clientErrorDetails struct {
+	StatusCode       int               `json:"statusCode"`
+	ValidationErrors map[string]string `json:"validationErrors,omitempty"`
+}
+
+// ClassifyError categorizes an error. Errors that are already classified are returned as is.
+func ClassifyError(err error) *Error {
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified
+	}
+
+	classified = &Error{Code: ErrorCodeGeneral, Message: err.Error(), Err: err}
+
+	var clientErr *sdk.ClientError
+	var validationErrs validation.Errors
+	var timeoutErr interface{ Timeout() bool }
+	var netErr net.Error
+	switch {
+	// Timeouts are checked first since a timeout may wrap the last error that was observed (e.g. a server error)
+	case errors.As(err, &timeoutErr) && timeoutErr.Timeout():
+		classified.Code = ErrorCodeTimeout
+	case errors.As(err, &clientErr):
+		classified.Code = classifyStatusCode(clientErr.StatusCode)
+		classified.Details = clientErrorDetails{StatusCode: clientErr.StatusCode, ValidationErrors: clientErr.ValidationErrors}
+	case errors.As(err, &validationErrs):
+		classified.Code = ErrorCodeValidation
+		details := map[string]string{}
+		for field, fieldErr := range validationErrs {
+			details[field] = fieldErr.Error()
+		}
+		classified.Details = details
+	case errors.As(err, &netErr):
+		classified.Code = ErrorCodeConnection
+	}
+
+	return classified
+}
+
+func classifyStatusCode(statusCode int) ErrorCode {
+	switch {
+	case statusCode == http.StatusBadRequest, statusCode == http.StatusUnprocessableEntity:
+		return ErrorCodeValidation
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return ErrorCodeAuth
+	case statusCode == http.StatusNotFound:
+		return ErrorCodeNotFound
+	case statusCode == http.StatusConflict:
+		return ErrorCodeConflict
+	case statusCode == http.StatusRequestTimeout, statusCode == http.StatusGatewayTimeout:
+		return ErrorCodeTimeout
+	case statusCode >= 500:
+		return ErrorCodeServer
+	}
+	return ErrorCodeGeneral
+}
+
+// withMessage returns a copy of the error with the message prefixed
+func (err *Error) withMessage(prefix string) *Error {
+	prefixed := *err
+	prefixed.Message = fmt.Sprintf("%s: %s", prefix, err.Message)
+	return &prefixed
+}
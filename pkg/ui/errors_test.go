@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v3"
+	"github.com/riser-platform/riser-server/pkg/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTimeoutError struct{}
+
+func (fakeTimeoutError) Error() string { return "timed out" }
+func (fakeTimeoutError) Timeout() bool { return true }
+
+func Test_ClassifyError(t *testing.T) {
+	tests := []struct {
+		err              error
+		expectedCode     ErrorCode
+		expectedExitCode int
+	}{
+		{errors.New("busted"), ErrorCodeGeneral, 1},
+		{&sdk.ClientError{StatusCode: 400}, ErrorCodeValidation, 3},
+		{&sdk.ClientError{StatusCode: 422}, ErrorCodeValidation, 3},
+		{&sdk.ClientError{StatusCode: 404}, ErrorCodeNotFound, 4},
+		{&sdk.ClientError{StatusCode: 401}, ErrorCodeAuth, 5},
+		{&sdk.ClientError{StatusCode: 403}, ErrorCodeAuth, 5},
+		{&sdk.ClientError{StatusCode: 504}, ErrorCodeTimeout, 6},
+		{&sdk.ClientError{StatusCode: 409}, ErrorCodeConflict, 7},
+		{&sdk.ClientError{StatusCode: 500}, ErrorCodeServer, 8},
+		{fmt.Errorf("wrapped: %w", &sdk.ClientError{StatusCode: 404}), ErrorCodeNotFound, 4},
+		{validation.Errors{"name": errors.New("required")}, ErrorCodeValidation, 3},
+		{fmt.Errorf("wrapped: %w", fakeTimeoutError{}), ErrorCodeTimeout, 6},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, ErrorCodeConnection, 9},
+		{NewError(ErrorCodeUsage, "bad flag"), ErrorCodeUsage, 2},
+	}
+
+	for _, tt := range tests {
+		result := ClassifyError(tt.err)
+		assert.Equal(t, tt.expectedCode, result.Code, tt.err.Error())
+		assert.Equal(t, tt.expectedExitCode, result.ExitCode(), tt.err.Error())
+		assert.Equal(t, tt.err.Error(), result.Message)
+	}
+}
+
+func Test_ClassifyError_ClientErrorDetails(t *testing.T) {
+	err := &sdk.ClientError{StatusCode: 400, Message: "Invalid app", ValidationErrors: map[string]string{"name": "required"}}
+
+	result := ClassifyError(err)
+
+	assert.Equal(t, clientErrorDetails{StatusCode: 400, ValidationErrors: map[string]string{"name": "required"}}, result.Details)
+	assert.True(t, errors.Is(result, err))
+}
+
+func Test_ExitError_Json(t *testing.T) {
+	exitCode := 0
+	originalExit := exit
+	exit = func(code int) { exitCode = code }
+	defer func() { exit = originalExit }()
+	SetOutputFormat(OutputFormatJson)
+	defer SetOutputFormat(OutputFormatHuman)
+
+	b := &bytes.Buffer{}
+	err := renderErrorJson(ClassifyError(&sdk.ClientError{StatusCode: 404, Message: "App not found"}).withMessage("Error getting status"), b)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"error":{"code":"NotFound","exitCode":4,"message":"Error getting status: Error: App not found","details":{"statusCode":404}}}`, b.String())
+
+	ExitError(NewError(ErrorCodeTimeout, "timeout"))
+	assert.Equal(t, 6, exitCode)
+}
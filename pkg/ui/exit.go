@@ -1,27 +1,70 @@
 package ui
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"riser/pkg/logger"
 )
 
-// ExitIfError exits if the error is not null and prints the error message
+// exit is a var so that it can be stubbed in tests
+var exit = os.Exit
+
+// ExitIfError exits if the error is not null and prints the error message. The exit code is determined by ClassifyError.
 func ExitIfError(err error) {
 	if err != nil {
-		ExitErrorMsg(err.Error())
+		ExitError(ClassifyError(err))
 	}
 }
 
 // ExitIfErrorMsg exits if the error is not null and prints a custom message with the error message
 func ExitIfErrorMsg(err error, exitMessage string) {
 	if err != nil {
-		ExitErrorMsg(fmt.Sprintf("%s: %s", exitMessage, err))
+		ExitError(ClassifyError(err).withMessage(exitMessage))
 	}
 }
 
 // ExitErrorMsg exits with error code 1 and prints a custom message
 func ExitErrorMsg(exitMessage string) {
-	logger.Log().Error(exitMessage)
-	os.Exit(1)
+	ExitError(NewError(ErrorCodeGeneral, exitMessage))
+}
+
+// ExitError prints the error and exits with the error's exit code. A JSON error envelope is printed to stderr
+// when the output format is JSON.
+func ExitError(err *Error) {
+	if outputFormat == OutputFormatJson {
+		_ = renderErrorJson(err, os.Stderr)
+	} else {
+		logger.Log().Error(err.Message)
+	}
+	exit(err.ExitCode())
+}
+
+type errorEnvelope struct {
+	Error errorEnvelopeError `json:"error"`
+}
+
+type errorEnvelopeError struct {
+	Code     ErrorCode   `json:"code"`
+	ExitCode int         `json:"exitCode"`
+	Message  string      `json:"message"`
+	Details  interface{} `json:"details,omitempty"`
+}
+
+func renderErrorJson(err *Error, writer io.Writer) error {
+	envelope := errorEnvelope{
+		Error: errorEnvelopeError{
+			Code:     err.Code,
+			ExitCode: err.ExitCode(),
+			Message:  err.Message,
+			Details:  err.Details,
+		},
+	}
+	outBytes, marshalErr := json.MarshalIndent(envelope, "", "    ")
+	if marshalErr != nil {
+		return marshalErr
+	}
+	_, writeErr := fmt.Fprintln(writer, string(outBytes))
+	return writeErr
 }
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"riser/pkg/ui/table"
+	"strings"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// ValidateOutputFormat returns an error if the output format is unknown or if a template cannot be parsed
+func ValidateOutputFormat(format string) error {
+	switch {
+	case format == OutputFormatHuman, format == OutputFormatJson, format == OutputFormatYaml, format == OutputFormatWide, format == OutputFormatCsv:
+		return nil
+	case strings.HasPrefix(format, OutputFormatJsonPathPrefix):
+		_, err := parseJsonPath(strings.TrimPrefix(format, OutputFormatJsonPathPrefix))
+		return err
+	case strings.HasPrefix(format, OutputFormatGoTemplatePrefix):
+		_, err := template.New("output").Parse(strings.TrimPrefix(format, OutputFormatGoTemplatePrefix))
+		return err
+	case strings.HasPrefix(format, OutputFormatCustomColumnsPrefix):
+		_, err := parseCustomColumns(strings.TrimPrefix(format, OutputFormatCustomColumnsPrefix))
+		return err
+	}
+
+	return fmt.Errorf("Must be one of: %s|%s|%s|%s|%s|%s...|%s...|%s...", OutputFormatHuman, OutputFormatJson, OutputFormatYaml, OutputFormatWide, OutputFormatCsv,
+		OutputFormatJsonPathPrefix, OutputFormatGoTemplatePrefix, OutputFormatCustomColumnsPrefix)
+}
+
+// structuredData renders the view's JSON and unmarshals it into generic data for use with templates
+func structuredData(view View) (interface{}, error) {
+	buffer := &bytes.Buffer{}
+	err := view.RenderJson(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	var data interface{}
+	decoder := json.NewDecoder(buffer)
+	// Prevent large numbers from being printed in scientific notation
+	decoder.UseNumber()
+	err = decoder.Decode(&data)
+	return data, err
+}
+
+func renderYaml(view View, writer io.Writer) error {
+	buffer := &bytes.Buffer{}
+	err := view.RenderJson(buffer)
+	if err != nil {
+		return err
+	}
+
+	yamlBytes, err := yaml.JSONToYAML(buffer.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(yamlBytes)
+	return err
+}
+
+func renderJsonPath(view View, jsonPathTemplate string, writer io.Writer) error {
+	parsed, err := parseJsonPath(jsonPathTemplate)
+	if err != nil {
+		return err
+	}
+
+	data, err := structuredData(view)
+	if err != nil {
+		return err
+	}
+
+	return parsed.Execute(writer, data)
+}
+
+func renderGoTemplate(view View, goTemplate string, writer io.Writer) error {
+	parsed, err := template.New("output").Parse(goTemplate)
+	if err != nil {
+		return err
+	}
+
+	data, err := structuredData(view)
+	if err != nil {
+		return err
+	}
+
+	return parsed.Execute(writer, data)
+}
+
+type customColumn struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+// parseCustomColumns parses a column spec in the format "HEADER1:.path1,HEADER2:.path2"
+func parseCustomColumns(spec string) ([]customColumn, error) {
+	columns := []customColumn{}
+	for _, columnSpec := range strings.Split(spec, ",") {
+		parts := strings.SplitN(columnSpec, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("custom column %q must be in the format \"HEADER:.path\"", columnSpec)
+		}
+		path, err := parseJsonPath(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, customColumn{header: parts[0], path: path})
+	}
+
+	return columns, nil
+}
+
+// renderCustomColumns renders a table with a row for each item when the view's JSON is an array, otherwise a single row
+func renderCustomColumns(view View, spec string, writer io.Writer) error {
+	columns, err := parseCustomColumns(spec)
+	if err != nil {
+		return err
+	}
+
+	data, err := structuredData(view)
+	if err != nil {
+		return err
+	}
+
+	items, isArray := data.([]interface{})
+	if !isArray {
+		items = []interface{}{data}
+	}
+
+	headers := []string{}
+	for _, column := range columns {
+		headers = append(headers, column.header)
+	}
+	outTable := table.Default().Header(headers...)
+
+	for _, item := range items {
+		row := []string{}
+		for _, column := range columns {
+			cell := &bytes.Buffer{}
+			err = column.path.Execute(cell, item)
+			if err != nil {
+				return err
+			}
+			row = append(row, cell.String())
+		}
+		outTable.AddRow(row...)
+	}
+
+	_, err = writer.Write([]byte(outTable.String() + "\n"))
+	return err
+}
+
+// parseJsonPath parses a JSONPath template. For convenience the template is wrapped in braces if none are specified (e.g. ".name" is treated as "{.name}")
+func parseJsonPath(jsonPathTemplate string) (*jsonpath.JSONPath, error) {
+	if !strings.Contains(jsonPathTemplate, "{") {
+		jsonPathTemplate = fmt.Sprintf("{%s}", jsonPathTemplate)
+	}
+
+	parsed := jsonpath.New("output").AllowMissingKeys(true)
+	err := parsed.Parse(jsonPathTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
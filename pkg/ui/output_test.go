@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStructuredView struct {
+	data interface{}
+}
+
+func (view *fakeStructuredView) RenderHuman(writer io.Writer) error {
+	_, err := writer.Write([]byte("human"))
+	return err
+}
+
+func (view *fakeStructuredView) RenderJson(writer io.Writer) error {
+	return RenderJson(view.data, writer)
+}
+
+type fakeWideView struct {
+	fakeStructuredView
+}
+
+func (view *fakeWideView) RenderWide(writer io.Writer) error {
+	_, err := writer.Write([]byte("wide"))
+	return err
+}
+
+func Test_RenderViewWriter_StructuredFormats(t *testing.T) {
+	data := []map[string]interface{}{
+		{"name": "a", "size": 1000000},
+		{"name": "b", "size": 2},
+	}
+	tests := []struct {
+		outputFormat string
+		expected     string
+	}{
+		{OutputFormatYaml, "- name: a\n  size: 1000000\n- name: b\n  size: 2\n"},
+		{"jsonpath={[*].name}", "a b"},
+		{"jsonpath=[0].size", "1000000"},
+		{"go-template={{range .}}{{.name}}={{.size}};{{end}}", "a=1000000;b=2;"},
+		{"custom-columns=NAME:.name,SIZE:.size", " NAME  SIZE    \n───────────────\n a     1000000 \n b     2       \n"},
+	}
+
+	for _, tt := range tests {
+		SetOutputFormat(tt.outputFormat)
+		var b bytes.Buffer
+
+		err := RenderViewWriter(&fakeStructuredView{data}, &b)
+
+		assert.NoError(t, err, tt.outputFormat)
+		assert.Equal(t, tt.expected, b.String(), tt.outputFormat)
+	}
+	SetOutputFormat(OutputFormatHuman)
+}
+
+func Test_RenderViewWriter_Wide(t *testing.T) {
+	SetOutputFormat(OutputFormatWide)
+	defer SetOutputFormat(OutputFormatHuman)
+	var wide bytes.Buffer
+	var human bytes.Buffer
+
+	assert.NoError(t, RenderViewWriter(&fakeWideView{}, &wide))
+	// Views without a wide rendering fall back to human
+	assert.NoError(t, RenderViewWriter(&fakeStructuredView{}, &human))
+
+	assert.Equal(t, "wide", wide.String())
+	assert.Equal(t, "human", human.String())
+}
+
+func Test_RenderViewWriter_Csv(t *testing.T) {
+	SetOutputFormat(OutputFormatCsv)
+	defer SetOutputFormat(OutputFormatHuman)
+	view := &BasicTableView{}
+	view.Header("Name", "Tag")
+	view.AddRow("a", "v1,rc1")
+	var b bytes.Buffer
+
+	assert.NoError(t, RenderViewWriter(view, &b))
+	err := RenderViewWriter(&fakeStructuredView{}, ioutil.Discard)
+
+	assert.Equal(t, "Name,Tag\na,\"v1,rc1\"\n", b.String())
+	assert.Equal(t, "This command does not support csv output", err.Error())
+	assert.Equal(t, ErrorCodeUsage, ClassifyError(err).Code)
+}
+
+func Test_ValidateOutputFormat(t *testing.T) {
+	tests := []struct {
+		outputFormat string
+		valid        bool
+	}{
+		{OutputFormatHuman, true},
+		{OutputFormatJson, true},
+		{OutputFormatYaml, true},
+		{OutputFormatWide, true},
+		{OutputFormatCsv, true},
+		{"jsonpath={.name}", true},
+		{"jsonpath={.name", false},
+		{"go-template={{.name}}", true},
+		{"go-template={{.name", false},
+		{"custom-columns=NAME:.name", true},
+		{"custom-columns=NAME", false},
+		{"xml", false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateOutputFormat(tt.outputFormat)
+		if tt.valid {
+			assert.NoError(t, err, tt.outputFormat)
+		} else {
+			assert.Error(t, err, tt.outputFormat)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package ui
 
 import (
+	"encoding/csv"
 	"io"
 	"os"
+	"strings"
 )
 
 const (
@@ -10,6 +12,18 @@ const (
 	OutputFormatHuman = "human"
 	// OutputFormatJson prints the output in JSON
 	OutputFormatJson = "json"
+	// OutputFormatYaml prints the output in YAML
+	OutputFormatYaml = "yaml"
+	// OutputFormatWide prints the output for humans with additional detail for views that implement WideView
+	OutputFormatWide = "wide"
+	// OutputFormatCsv prints the output as CSV for views that implement CsvView
+	OutputFormatCsv = "csv"
+	// OutputFormatJsonPathPrefix prints the output using a JSONPath template (e.g. "jsonpath={.name}")
+	OutputFormatJsonPathPrefix = "jsonpath="
+	// OutputFormatGoTemplatePrefix prints the output using a go template (e.g. "go-template={{.name}}")
+	OutputFormatGoTemplatePrefix = "go-template="
+	// OutputFormatCustomColumnsPrefix prints the output as a table using JSONPath columns (e.g. "custom-columns=NAME:.name")
+	OutputFormatCustomColumnsPrefix = "custom-columns="
 )
 
 var outputFormat string
@@ -21,15 +35,45 @@ func RenderView(view View) {
 	ExitIfError(RenderViewWriter(view, os.Stdout))
 }
 
+// RenderViewWriter renders a view using the global output format. Structured formats other than JSON (e.g. YAML, templates)
+// are rendered from the view's JSON so that every view supports them.
 func RenderViewWriter(view View, writer io.Writer) error {
-	switch outputFormat {
-	case OutputFormatJson:
+	switch {
+	case outputFormat == OutputFormatJson:
 		return view.RenderJson(writer)
+	case outputFormat == OutputFormatYaml:
+		return renderYaml(view, writer)
+	case outputFormat == OutputFormatWide:
+		if wideView, ok := view.(WideView); ok {
+			return wideView.RenderWide(writer)
+		}
+		return view.RenderHuman(writer)
+	case outputFormat == OutputFormatCsv:
+		if csvView, ok := view.(CsvView); ok {
+			return csvView.RenderCsv(writer)
+		}
+		return NewError(ErrorCodeUsage, "This command does not support csv output")
+	case strings.HasPrefix(outputFormat, OutputFormatJsonPathPrefix):
+		return renderJsonPath(view, strings.TrimPrefix(outputFormat, OutputFormatJsonPathPrefix), writer)
+	case strings.HasPrefix(outputFormat, OutputFormatGoTemplatePrefix):
+		return renderGoTemplate(view, strings.TrimPrefix(outputFormat, OutputFormatGoTemplatePrefix), writer)
+	case strings.HasPrefix(outputFormat, OutputFormatCustomColumnsPrefix):
+		return renderCustomColumns(view, strings.TrimPrefix(outputFormat, OutputFormatCustomColumnsPrefix), writer)
 	default:
 		return view.RenderHuman(writer)
 	}
 }
 
+// RenderCsv renders records as CSV
+func RenderCsv(records [][]string, writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.WriteAll(records)
+	if err != nil {
+		return err
+	}
+	return csvWriter.Error()
+}
+
 // SetOutputFormat sets the global output format for all calls to RenderView* funcs
 func SetOutputFormat(newOutputFormat string) {
 	outputFormat = newOutputFormat
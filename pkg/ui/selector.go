@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	SelectorOperatorEquals    = "="
+	SelectorOperatorNotEquals = "!="
+	SelectorOperatorMatches   = "~="
+)
+
+// Selector is a list of requirements that must all match
+type Selector []SelectorRequirement
+
+// SelectorRequirement matches a column's value. Keys are case insensitive.
+type SelectorRequirement struct {
+	Key      string
+	Operator string
+	Value    string
+	pattern  *regexp.Regexp
+}
+
+// ParseSelector parses a comma separated list of requirements in the form key=value, key!=value, or key~=pattern where
+// pattern is a regular expression (e.g. "name~=^api-,namespace=apps")
+func ParseSelector(selectorStr string) (Selector, error) {
+	selector := Selector{}
+	if strings.TrimSpace(selectorStr) == "" {
+		return selector, nil
+	}
+
+	for _, requirementStr := range strings.Split(selectorStr, ",") {
+		requirement, err := parseSelectorRequirement(strings.TrimSpace(requirementStr))
+		if err != nil {
+			return nil, err
+		}
+		selector = append(selector, *requirement)
+	}
+
+	return selector, nil
+}
+
+func parseSelectorRequirement(requirementStr string) (*SelectorRequirement, error) {
+	// Order matters since "=" is a suffix of the other operators
+	for _, operator := range []string{SelectorOperatorMatches, SelectorOperatorNotEquals, "==", SelectorOperatorEquals} {
+		idx := strings.Index(requirementStr, operator)
+		if idx < 0 {
+			continue
+		}
+
+		requirement := &SelectorRequirement{
+			Key:      strings.TrimSpace(requirementStr[:idx]),
+			Operator: operator,
+			Value:    strings.TrimSpace(requirementStr[idx+len(operator):]),
+		}
+		if requirement.Key == "" {
+			return nil, fmt.Errorf("invalid selector %q: a key is required", requirementStr)
+		}
+		if operator == "==" {
+			requirement.Operator = SelectorOperatorEquals
+		}
+		if operator == SelectorOperatorMatches {
+			pattern, err := regexp.Compile(requirement.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector %q: %v", requirementStr, err)
+			}
+			requirement.pattern = pattern
+		}
+		return requirement, nil
+	}
+
+	return nil, fmt.Errorf("invalid selector %q: must be in the form key=value, key!=value, or key~=pattern", requirementStr)
+}
+
+// Matches returns true if the value satisfies the requirement
+func (requirement *SelectorRequirement) Matches(value string) bool {
+	switch requirement.Operator {
+	case SelectorOperatorNotEquals:
+		return value != requirement.Value
+	case SelectorOperatorMatches:
+		return requirement.pattern.MatchString(value)
+	default:
+		return value == requirement.Value
+	}
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		expected []SelectorRequirement
+		err      string
+	}{
+		{"", []SelectorRequirement{}, ""},
+		{"name=foo", []SelectorRequirement{{Key: "name", Operator: "=", Value: "foo"}}, ""},
+		{"name==foo", []SelectorRequirement{{Key: "name", Operator: "=", Value: "foo"}}, ""},
+		{"name!=foo, namespace = apps", []SelectorRequirement{{Key: "name", Operator: "!=", Value: "foo"}, {Key: "namespace", Operator: "=", Value: "apps"}}, ""},
+		{"name~=^foo", []SelectorRequirement{{Key: "name", Operator: "~=", Value: "^foo"}}, ""},
+		{"name", nil, `invalid selector "name": must be in the form key=value, key!=value, or key~=pattern`},
+		{"=foo", nil, `invalid selector "=foo": a key is required`},
+	}
+
+	for _, tt := range tests {
+		result, err := ParseSelector(tt.selector)
+
+		if tt.err != "" {
+			assert.Equal(t, tt.err, err.Error())
+			continue
+		}
+		require.NoError(t, err)
+		require.Len(t, result, len(tt.expected), tt.selector)
+		for idx, requirement := range result {
+			assert.Equal(t, tt.expected[idx].Key, requirement.Key)
+			assert.Equal(t, tt.expected[idx].Operator, requirement.Operator)
+			assert.Equal(t, tt.expected[idx].Value, requirement.Value)
+		}
+	}
+}
+
+func Test_SelectorRequirement_Matches(t *testing.T) {
+	tests := []struct {
+		selector string
+		value    string
+		expected bool
+	}{
+		{"name=foo", "foo", true},
+		{"name=foo", "foobar", false},
+		{"name!=foo", "foo", false},
+		{"name!=foo", "bar", true},
+		{"name~=^foo", "foobar", true},
+		{"name~=^foo", "barfoo", false},
+	}
+
+	for _, tt := range tests {
+		selector, err := ParseSelector(tt.selector)
+		require.NoError(t, err)
+
+		assert.Equal(t, tt.expected, selector[0].Matches(tt.value), "%s %s", tt.selector, tt.value)
+	}
+}
@@ -2,10 +2,52 @@ package style
 
 import (
 	"fmt"
+	"os"
+	"riser/pkg/ui/terminal"
 
 	"github.com/wzshiming/ctc"
 )
 
+const (
+	// ColorModeAuto only uses color when writing to a terminal and the NO_COLOR environment variable is not set
+	ColorModeAuto = "auto"
+	// ColorModeAlways always uses color
+	ColorModeAlways = "always"
+	// ColorModeNever never uses color
+	ColorModeNever = "never"
+)
+
+var colorMode = ColorModeAuto
+
+// isTerminal is a var so that it can be stubbed in tests
+var isTerminal = terminal.IsStdoutTerminal
+
+// SetColorMode sets the color mode. Must be one of ColorModeAuto, ColorModeAlways, or ColorModeNever.
+func SetColorMode(newColorMode string) error {
+	switch newColorMode {
+	case ColorModeAuto, ColorModeAlways, ColorModeNever:
+		colorMode = newColorMode
+		return nil
+	}
+	return fmt.Errorf("Invalid color mode %q. Must be one of: %s, %s, %s", newColorMode, ColorModeAuto, ColorModeAlways, ColorModeNever)
+}
+
+// ColorEnabled returns true if messages should be colorized. See https://no-color.org for the NO_COLOR convention.
+func ColorEnabled() bool {
+	switch colorMode {
+	case ColorModeAlways:
+		return true
+	case ColorModeNever:
+		return false
+	}
+
+	if _, noColor := os.LookupEnv("NO_COLOR"); noColor {
+		return false
+	}
+
+	return os.Getenv("TERM") != "dumb" && isTerminal()
+}
+
 func Good(message string) string {
 	return Colorize(message, ctc.ForegroundBrightGreen)
 }
@@ -14,6 +56,10 @@ func Emphasis(message string) string {
 	return Colorize(message, ctc.ForegroundBrightCyan)
 }
 
+func Strong(message string) string {
+	return Colorize(message, ctc.ForegroundBrightWhite)
+}
+
 func Muted(message string) string {
 	return Colorize(message, ctc.ForegroundBrightBlack)
 }
@@ -26,6 +72,10 @@ func Warn(message string) string {
 	return Colorize(message, ctc.ForegroundBrightYellow)
 }
 
+// Colorize colorizes a message. The message is returned as is if color is not enabled.
 func Colorize(message string, color ctc.Color) string {
+	if !ColorEnabled() {
+		return message
+	}
 	return fmt.Sprintf("%s%s%s", color, message, ctc.Reset)
 }
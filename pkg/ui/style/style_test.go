@@ -0,0 +1,70 @@
+package style
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wzshiming/ctc"
+)
+
+func Test_ColorEnabled(t *testing.T) {
+	originalIsTerminal := isTerminal
+	originalTerm := os.Getenv("TERM")
+	originalNoColor, noColorSet := os.LookupEnv("NO_COLOR")
+	defer func() {
+		isTerminal = originalIsTerminal
+		colorMode = ColorModeAuto
+		os.Setenv("TERM", originalTerm)
+		if noColorSet {
+			os.Setenv("NO_COLOR", originalNoColor)
+		} else {
+			os.Unsetenv("NO_COLOR")
+		}
+	}()
+
+	tests := []struct {
+		colorMode  string
+		isTerminal bool
+		noColor    bool
+		term       string
+		expected   bool
+	}{
+		{ColorModeAuto, true, false, "xterm", true},
+		{ColorModeAuto, false, false, "xterm", false},
+		{ColorModeAuto, true, true, "xterm", false},
+		{ColorModeAuto, true, false, "dumb", false},
+		{ColorModeAlways, false, true, "dumb", true},
+		{ColorModeNever, true, false, "xterm", false},
+	}
+
+	for _, tt := range tests {
+		isTerminal = func() bool { return tt.isTerminal }
+		assert.NoError(t, SetColorMode(tt.colorMode))
+		os.Setenv("TERM", tt.term)
+		if tt.noColor {
+			os.Setenv("NO_COLOR", "")
+		} else {
+			os.Unsetenv("NO_COLOR")
+		}
+
+		assert.Equal(t, tt.expected, ColorEnabled(), "%#v", tt)
+	}
+}
+
+func Test_Colorize(t *testing.T) {
+	defer func() { colorMode = ColorModeAuto }()
+
+	assert.NoError(t, SetColorMode(ColorModeAlways))
+	assert.Equal(t, ctc.ForegroundBrightGreen.String()+"foo"+ctc.Reset.String(), Good("foo"))
+
+	assert.NoError(t, SetColorMode(ColorModeNever))
+	assert.Equal(t, "foo", Good("foo"))
+}
+
+func Test_SetColorMode_Invalid(t *testing.T) {
+	err := SetColorMode("sometimes")
+
+	assert.Equal(t, `Invalid color mode "sometimes". Must be one of: auto, always, never`, err.Error())
+	assert.Equal(t, ColorModeAuto, colorMode)
+}
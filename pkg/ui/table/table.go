@@ -1,13 +1,27 @@
 package table
 
 import (
+	"riser/pkg/ui/terminal"
+	"sort"
+
 	"github.com/jedib0t/go-pretty/table"
+	"github.com/jedib0t/go-pretty/text"
 )
 
+// defaultMaxColumnLength is used when the width of the terminal cannot be determined (e.g. when output is piped)
 const defaultMaxColumnLength = 50
 
+// minColumnLength prevents columns from becoming unreadable on narrow terminals
+const minColumnLength = 10
+
+// columnPadding is the padding on either side of each column
+const columnPadding = 2
+
 type Table struct {
-	internal table.Writer
+	internal      table.Writer
+	columnLengths []int
+	// terminalWidth is a func so that it can be stubbed in tests
+	terminalWidth func() int
 }
 
 // Default creates a default table
@@ -16,16 +30,11 @@ func Default() *Table {
 	internal.SetStyle(table.StyleLight)
 	internal.Style().Options.DrawBorder = false
 	internal.Style().Options.SeparateColumns = false
-	return &Table{internal}
+	return &Table{internal: internal, terminalWidth: terminal.Width}
 }
 
 func (t *Table) Header(values ...string) *Table {
 	t.internal.AppendHeader(t.createRow(values))
-	columnLengths := []int{}
-	for range values {
-		columnLengths = append(columnLengths, defaultMaxColumnLength)
-	}
-	t.internal.SetAllowedColumnLengths(columnLengths)
 	return t
 }
 
@@ -35,13 +44,56 @@ func (t *Table) AddRow(values ...string) *Table {
 }
 
 func (t *Table) String() string {
+	t.internal.SetAllowedColumnLengths(allowedColumnLengths(t.columnLengths, t.terminalWidth()))
 	return t.internal.Render()
 }
 
 func (t *Table) createRow(values []string) table.Row {
 	row := table.Row{}
-	for _, v := range values {
+	for idx, v := range values {
 		row = append(row, v)
+		if idx >= len(t.columnLengths) {
+			t.columnLengths = append(t.columnLengths, 0)
+		}
+		if length := text.LongestLineLen(v); length > t.columnLengths[idx] {
+			t.columnLengths[idx] = length
+		}
 	}
 	return row
 }
+
+// allowedColumnLengths fits the columns to the terminal width. Narrow columns keep their length and the remaining width
+// is shared evenly between the wider columns. The defaultMaxColumnLength is used when the terminal width is unknown.
+func allowedColumnLengths(columnLengths []int, terminalWidth int) []int {
+	allowed := make([]int, len(columnLengths))
+	if terminalWidth <= 0 {
+		for idx := range allowed {
+			allowed[idx] = defaultMaxColumnLength
+		}
+		return allowed
+	}
+
+	columnIndexes := make([]int, len(columnLengths))
+	for idx := range columnIndexes {
+		columnIndexes[idx] = idx
+	}
+	sort.SliceStable(columnIndexes, func(i, j int) bool {
+		return columnLengths[columnIndexes[i]] < columnLengths[columnIndexes[j]]
+	})
+
+	remainingWidth := terminalWidth - (len(columnLengths) * columnPadding)
+	for position, columnIdx := range columnIndexes {
+		share := remainingWidth / (len(columnIndexes) - position)
+		if share < minColumnLength {
+			share = minColumnLength
+		}
+		if columnLengths[columnIdx] <= share {
+			allowed[columnIdx] = columnLengths[columnIdx]
+		} else {
+			allowed[columnIdx] = share
+		}
+		remainingWidth -= allowed[columnIdx]
+	}
+
+	return allowed
+}
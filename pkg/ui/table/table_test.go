@@ -0,0 +1,36 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_allowedColumnLengths(t *testing.T) {
+	tests := []struct {
+		test          string
+		columnLengths []int
+		terminalWidth int
+		expected      []int
+	}{
+		{"unknown width", []int{5, 100}, 0, []int{50, 50}},
+		{"fits", []int{5, 20, 10}, 80, []int{5, 20, 10}},
+		{"wide column shrinks", []int{5, 100, 10}, 80, []int{5, 59, 10}},
+		{"wide columns share", []int{100, 5, 100}, 80, []int{34, 5, 35}},
+		{"minimum length", []int{100, 100}, 10, []int{10, 10}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, allowedColumnLengths(tt.columnLengths, tt.terminalWidth), tt.test)
+	}
+}
+
+func Test_Table_String_FitsTerminalWidth(t *testing.T) {
+	table := Default().Header("Name", "Description").AddRow("foo", "one two three four five six seven")
+	table.terminalWidth = func() int { return 26 }
+
+	assert.Equal(t, " NAME  DESCRIPTION        \n"+
+		"──────────────────────────\n"+
+		" foo   one two three four \n"+
+		"        five six seven    ", table.String())
+}
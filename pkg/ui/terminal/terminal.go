@@ -0,0 +1,58 @@
+// Package terminal provides information about the terminal that riser is writing to
+package terminal
+
+import (
+	"os"
+	"strconv"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// IsStdoutTerminal returns true if stdout is a terminal (i.e. not piped or redirected to a file)
+func IsStdoutTerminal() bool {
+	return terminal.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// IsStdinTerminal returns true if stdin is a terminal (i.e. the user can be prompted for input)
+func IsStdinTerminal() bool {
+	return terminal.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// Width returns the width of the terminal in columns. The COLUMNS environment variable takes precedence over the size
+// of the terminal. Returns 0 if the width cannot be determined (e.g. when stdout is not a terminal).
+func Width() int {
+	if columns, ok := sizeFromEnv("COLUMNS"); ok {
+		return columns
+	}
+
+	width, _ := size()
+	return width
+}
+
+// Height returns the height of the terminal in lines. The LINES environment variable takes precedence over the size
+// of the terminal. Returns 0 if the height cannot be determined (e.g. when stdout is not a terminal).
+func Height() int {
+	if lines, ok := sizeFromEnv("LINES"); ok {
+		return lines
+	}
+
+	_, height := size()
+	return height
+}
+
+func sizeFromEnv(envVar string) (int, bool) {
+	value, err := strconv.Atoi(os.Getenv(envVar))
+	return value, err == nil && value > 0
+}
+
+func size() (width int, height int) {
+	if !IsStdoutTerminal() {
+		return 0, 0
+	}
+
+	width, height, err := terminal.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0, 0
+	}
+	return width, height
+}
@@ -8,3 +8,13 @@ type View interface {
 	RenderHuman(io.Writer) error
 	RenderJson(io.Writer) error
 }
+
+// WideView is implemented by views that render additional detail for humans with "-o wide"
+type WideView interface {
+	RenderWide(io.Writer) error
+}
+
+// CsvView is implemented by views that can be rendered as CSV with "-o csv"
+type CsvView interface {
+	RenderCsv(io.Writer) error
+}
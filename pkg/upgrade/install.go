@@ -0,0 +1,80 @@
+package upgrade
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// ExtractBinary extracts the riser binary from a release artifact zip
+func ExtractBinary(artifactBytes []byte) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(artifactBytes), int64(len(artifactBytes)))
+	if err != nil {
+		return nil, err
+	}
+
+	binaryName := "riser"
+	if runtime.GOOS == "windows" {
+		binaryName = "riser.exe"
+	}
+
+	for _, file := range reader.File {
+		if filepath.Base(file.Name) != binaryName {
+			continue
+		}
+		fileReader, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer fileReader.Close()
+		return ioutil.ReadAll(fileReader)
+	}
+
+	return nil, fmt.Errorf("the release artifact does not contain %q", binaryName)
+}
+
+// ReplaceExecutable atomically replaces the executable at the specified path by writing the new binary to a temp file in
+// the same directory and renaming it over the executable.
+func ReplaceExecutable(executablePath string, binary []byte) error {
+	info, err := os.Stat(executablePath)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(executablePath)
+	tmpFile, err := ioutil.TempFile(dir, filepath.Base(executablePath)+".upgrade")
+	if err != nil {
+		return err
+	}
+	// Clean up on failure. This is a noop after a successful rename.
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(binary)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tmpFile.Name(), info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	// Windows does not allow replacing a running executable but does allow renaming it
+	if runtime.GOOS == "windows" {
+		oldPath := executablePath + ".old"
+		_ = os.Remove(oldPath)
+		err = os.Rename(executablePath, oldPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return os.Rename(tmpFile.Name(), executablePath)
+}
@@ -0,0 +1,97 @@
+// Package upgrade provides self-update of the riser CLI from a signed release manifest.
+package upgrade
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/pkg/errors"
+)
+
+// DefaultManifestURL is the location of the release manifest for the latest riser release
+const DefaultManifestURL = "https://github.com/riser-platform/riser/releases/latest/download/riser-manifest.json"
+
+const httpTimeout = 5 * time.Minute
+
+// Manifest describes a riser release
+type Manifest struct {
+	Version   string     `json:"version"`
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+// Artifact is a zip containing the riser binary for a specific platform
+type Artifact struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+	URL  string `json:"url"`
+	// SHA256 is the hex encoded checksum of the artifact
+	SHA256 string `json:"sha256"`
+	// Signature is the base64 encoded ed25519 signature of the release version, os, arch, and sha256. See VerifyArtifact.
+	Signature string `json:"signature"`
+}
+
+// ParsedVersion returns the release version
+func (manifest *Manifest) ParsedVersion() (*version.Version, error) {
+	return version.NewVersion(manifest.Version)
+}
+
+// Artifact returns the artifact for the specified platform
+func (manifest *Manifest) Artifact(goos, goarch string) (*Artifact, error) {
+	for idx := range manifest.Artifacts {
+		if manifest.Artifacts[idx].OS == goos && manifest.Artifacts[idx].Arch == goarch {
+			return &manifest.Artifacts[idx], nil
+		}
+	}
+
+	return nil, fmt.Errorf("release %s does not contain an artifact for %s-%s", manifest.Version, goos, goarch)
+}
+
+// FetchManifest retrieves the release manifest
+func FetchManifest(manifestURL string) (*Manifest, error) {
+	manifestBytes, err := download(manifestURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "error downloading release manifest")
+	}
+
+	manifest := &Manifest{}
+	err = json.Unmarshal(manifestBytes, manifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing release manifest")
+	}
+
+	return manifest, nil
+}
+
+// DownloadArtifact retrieves an artifact for the release version and verifies its checksum and signature
+func DownloadArtifact(releaseVersion string, artifact *Artifact, publicKey []byte) ([]byte, error) {
+	artifactBytes, err := download(artifact.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "error downloading release artifact")
+	}
+
+	err = VerifyArtifact(artifactBytes, releaseVersion, artifact, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return artifactBytes, nil
+}
+
+func download(url string) ([]byte, error) {
+	client := &http.Client{Timeout: httpTimeout}
+	response, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned status %d", url, response.StatusCode)
+	}
+
+	return ioutil.ReadAll(response.Body)
+}
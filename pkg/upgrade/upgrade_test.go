@@ -0,0 +1,141 @@
+package upgrade
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FetchManifest_DownloadArtifact(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	artifactBytes := createArtifact(t, "riser", "binary")
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	manifest := &Manifest{
+		Version:   "0.0.2",
+		Artifacts: []Artifact{SignArtifact(artifactBytes, privateKey, "0.0.2", runtime.GOOS, runtime.GOARCH, server.URL+"/riser.zip")},
+	}
+	mux.HandleFunc("/manifest.json", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(manifest))
+	})
+	mux.HandleFunc("/riser.zip", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(artifactBytes)
+	})
+
+	result, err := FetchManifest(server.URL + "/manifest.json")
+	require.NoError(t, err)
+	artifact, err := result.Artifact(runtime.GOOS, runtime.GOARCH)
+	require.NoError(t, err)
+	downloaded, err := DownloadArtifact(result.Version, artifact, publicKey)
+
+	assert.NoError(t, err)
+	assert.Equal(t, artifactBytes, downloaded)
+}
+
+func Test_FetchManifest_ReturnsError_WhenNotFound(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	result, err := FetchManifest(server.URL + "/manifest.json")
+
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "returned status 404")
+}
+
+func Test_Manifest_Artifact_ReturnsError_WhenMissingPlatform(t *testing.T) {
+	manifest := &Manifest{Version: "0.0.2"}
+
+	result, err := manifest.Artifact("plan9", "arm")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "release 0.0.2 does not contain an artifact for plan9-arm", err.Error())
+}
+
+func Test_VerifyArtifact(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	artifact := SignArtifact([]byte("artifact"), privateKey, "0.0.2", "linux", "amd64", "url")
+
+	assert.NoError(t, VerifyArtifact([]byte("artifact"), "0.0.2", &artifact, publicKey))
+	assert.Equal(t, "checksum mismatch for url", VerifyArtifact([]byte("tampered"), "0.0.2", &artifact, publicKey).Error())
+	assert.Equal(t, "signature verification failed for url", VerifyArtifact([]byte("artifact"), "0.0.2", &artifact, otherPublicKey).Error())
+	// The version and platform are signed
+	assert.Equal(t, "signature verification failed for url", VerifyArtifact([]byte("artifact"), "0.0.3", &artifact, publicKey).Error())
+	otherPlatform := artifact
+	otherPlatform.OS = "darwin"
+	assert.Equal(t, "signature verification failed for url", VerifyArtifact([]byte("artifact"), "0.0.2", &otherPlatform, publicKey).Error())
+}
+
+func Test_DecodePublicKey(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	result, err := DecodePublicKey(base64.StdEncoding.EncodeToString(publicKey))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(publicKey), result)
+
+	_, err = DecodePublicKey("")
+	assert.Equal(t, "no release public key is configured", err.Error())
+
+	_, err = DecodePublicKey(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.Equal(t, "invalid release public key: must be 32 bytes", err.Error())
+}
+
+func Test_ExtractBinary_ReplaceExecutable(t *testing.T) {
+	binaryName := "riser"
+	if runtime.GOOS == "windows" {
+		binaryName = "riser.exe"
+	}
+	tmpDir, err := ioutil.TempDir("", "riserupgrade")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	executablePath := filepath.Join(tmpDir, binaryName)
+	require.NoError(t, ioutil.WriteFile(executablePath, []byte("old"), 0755))
+
+	binary, err := ExtractBinary(createArtifact(t, binaryName, "new"))
+	require.NoError(t, err)
+	err = ReplaceExecutable(executablePath, binary)
+
+	assert.NoError(t, err)
+	result, err := ioutil.ReadFile(executablePath)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(result))
+	info, err := os.Stat(executablePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+}
+
+func Test_ExtractBinary_ReturnsError_WhenMissingBinary(t *testing.T) {
+	result, err := ExtractBinary(createArtifact(t, "README.md", "readme"))
+
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "the release artifact does not contain")
+}
+
+func createArtifact(t *testing.T, fileName, contents string) []byte {
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	file, err := writer.Create(fileName)
+	require.NoError(t, err)
+	_, err = file.Write([]byte(contents))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
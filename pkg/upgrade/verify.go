@@ -0,0 +1,77 @@
+package upgrade
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// releasePublicKey is the base64 encoded ed25519 public key used to verify releases.
+// It is overwritten by the compiler using ldflags.
+var releasePublicKey = ""
+
+// ReleasePublicKey returns the public key that this build of riser was released with
+func ReleasePublicKey() string {
+	return releasePublicKey
+}
+
+// DecodePublicKey decodes a base64 encoded ed25519 public key
+func DecodePublicKey(encodedKey string) ([]byte, error) {
+	if encodedKey == "" {
+		return nil, errors.New("no release public key is configured")
+	}
+
+	publicKey, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release public key: %v", err)
+	}
+
+	if len(publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid release public key: must be %d bytes", ed25519.PublicKeySize)
+	}
+
+	return publicKey, nil
+}
+
+// VerifyArtifact verifies an artifact's checksum and signature. The signature covers the release version and the
+// artifact's platform in addition to its checksum so that a validly signed artifact cannot be presented as a different
+// release (e.g. an older release with a newer version to force a downgrade) or for a different platform.
+func VerifyArtifact(artifactBytes []byte, releaseVersion string, artifact *Artifact, publicKey []byte) error {
+	checksum := sha256.Sum256(artifactBytes)
+	if hex.EncodeToString(checksum[:]) != artifact.SHA256 {
+		return fmt.Errorf("checksum mismatch for %s", artifact.URL)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(artifact.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature for %s: %v", artifact.URL, err)
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(publicKey), signedPayload(releaseVersion, artifact), signature) {
+		return fmt.Errorf("signature verification failed for %s", artifact.URL)
+	}
+
+	return nil
+}
+
+// SignArtifact creates a manifest artifact entry with the checksum of the artifact and a signature of the release version,
+// platform, and checksum
+func SignArtifact(artifactBytes []byte, privateKey ed25519.PrivateKey, releaseVersion, goos, goarch, url string) Artifact {
+	checksum := sha256.Sum256(artifactBytes)
+	artifact := Artifact{
+		OS:     goos,
+		Arch:   goarch,
+		URL:    url,
+		SHA256: hex.EncodeToString(checksum[:]),
+	}
+	artifact.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, signedPayload(releaseVersion, &artifact)))
+	return artifact
+}
+
+// signedPayload returns the bytes that are signed for an artifact. The URL is not signed since the checksum identifies the artifact.
+func signedPayload(releaseVersion string, artifact *Artifact) []byte {
+	return []byte(fmt.Sprintf("riser-release\nversion:%s\nos:%s\narch:%s\nsha256:%s\n", releaseVersion, artifact.OS, artifact.Arch, artifact.SHA256))
+}
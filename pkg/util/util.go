@@ -1,5 +1,9 @@
 package util
 
+func PtrInt(v int) *int {
+	return &v
+}
+
 func PtrInt32(v int32) *int32 {
 	return &v
 }
@@ -11,3 +15,7 @@ func PtrInt64(v int64) *int64 {
 func PtrBool(v bool) *bool {
 	return &v
 }
+
+func PtrFloat32(v float32) *float32 {
+	return &v
+}